@@ -1,13 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/OJarrisonn/medik/pkg/config"
-	"github.com/OJarrisonn/medik/pkg/format"
 	"github.com/OJarrisonn/medik/pkg/medik"
-	"github.com/OJarrisonn/medik/pkg/parse"
 	"github.com/OJarrisonn/medik/pkg/runner"
 	"github.com/spf13/cobra"
 )
@@ -20,13 +21,27 @@ var rootCmd = &cobra.Command{
 	Run:     run,
 }
 
+// The settings of the run, filled from the flags
+var options = runner.New()
+
+// Where the output is written instead of stdout, if set
+var outputFile string
+
 var lessVerbose,
 	moreVerbose int
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&medik.ConfigFile, "config", "c", medik.DefaultConfigFile, "Config file to use")
-	rootCmd.PersistentFlags().StringVarP(&medik.EnvFile, "env", "e", medik.DefaultEnvFile, "Env file to use")
-	rootCmd.PersistentFlags().BoolVar(&medik.NoColor, "no-color", medik.DefaultNoColor, "No color output")
+	rootCmd.PersistentFlags().StringVarP(&options.ConfigFile, "config", "c", medik.DefaultConfigFile, "Config file to use")
+	rootCmd.PersistentFlags().StringArrayVarP(&options.EnvFiles, "env", "e", nil, "Env file to use, can be repeated (later files win)")
+	rootCmd.PersistentFlags().BoolVar(&options.EnvOnly, "env-only", false, "Examine only the variables of the env files, ignoring the process environment")
+	rootCmd.PersistentFlags().BoolVar(&options.NoColor, "no-color", medik.DefaultNoColor, "No color output")
+	rootCmd.PersistentFlags().StringVarP(&options.Output, "output", "o", medik.DefaultOutput, "Output format (text, json, junit)")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", medik.DefaultOutputFile, "Write the output to a file instead of stdout")
+	rootCmd.PersistentFlags().BoolVar(&options.JUnitSkipWarnings, "junit-skip-warnings", false, "Report warnings as skipped test cases in the JUnit output")
+	rootCmd.PersistentFlags().IntVarP(&options.Jobs, "jobs", "j", medik.DefaultJobs, "Number of exams to run in parallel (overrides `jobs` in the config file)")
+	rootCmd.PersistentFlags().DurationVar(&options.Timeout, "timeout", 0, "Default timeout for each exam, like 30s (overrides `timeout` in the config file)")
+	rootCmd.PersistentFlags().BoolVar(&options.ShowSecrets, "show-secrets", false, "Show the values of secret variables in reports instead of masking them")
+	rootCmd.PersistentFlags().BoolVar(&options.NoStrict, "no-strict", false, "Accept unknown fields in the config file (overrides `strict` in the config file)")
 	rootCmd.PersistentFlags().CountVarP(&moreVerbose, "verbose", "v", "Increase verbosity")
 	rootCmd.PersistentFlags().CountVarP(&lessVerbose, "less-verbose", "V", "Decrease verbosity")
 
@@ -57,89 +72,43 @@ func setVerbosity() {
 	}
 
 	if moreVerbose > 0 || lessVerbose > 0 {
-		medik.Verbosity += lessVerbose - moreVerbose
-		if medik.Verbosity < 0 {
-			medik.Verbosity = 0
-		} else if medik.Verbosity > medik.MAX_LEVEL {
-			medik.Verbosity = medik.MAX_LEVEL
+		options.Verbosity += lessVerbose - moreVerbose
+		if options.Verbosity < 0 {
+			options.Verbosity = 0
+		} else if options.Verbosity > medik.MAX_LEVEL {
+			options.Verbosity = medik.MAX_LEVEL
 		}
 	}
 }
 
 func run(cmd *cobra.Command, args []string) {
-	cfg, err := loadConfig()
-	if err != nil {
-		fmt.Printf("Error loading config: %s\n", err)
-		os.Exit(1)
-	}
+	options.Protocols = args
+	options.Out = os.Stdout
 
-	_, err = loadEnv()
-	if err != nil {
-		fmt.Printf("Error loading env: %s\n", err)
-		os.Exit(1)
+	// The output file is only written once the run is done, so an invalid config doesn't leave an empty one behind
+	output := &bytes.Buffer{}
+	if outputFile != "" {
+		options.Out = output
 	}
 
-	success, reports, err := runner.Run(cfg, args)
+	// Exams still running on Ctrl-C are reported as cancelled instead of being left behind
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	result, err := options.Run(ctx)
+	stop()
+
 	if err != nil {
 		fmt.Printf("Error running medik: %s\n", err)
 		os.Exit(1)
 	}
 
-	for _, e := range reports {
-		ok, header, body := e.Format(medik.Verbosity)
-
-		if ok < medik.Verbosity {
-			continue
-		}
-
-		fmt.Println(header)
-
-		if body != "" {
-			fmt.Println(body)
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, output.Bytes(), 0o666); err != nil {
+			fmt.Printf("Error writing output: %s\n", err)
+			os.Exit(1)
 		}
 	}
 
-	fmt.Println(format.EnvironmentHealth(success))
-
-	if success >= medik.ERROR {
+	if !result.Healthy() {
 		os.Exit(1)
 	}
 }
-
-func loadConfig() (*config.Medik, error) {
-	content, err := os.ReadFile(medik.ConfigFile)
-	if err != nil {
-		return nil, err
-	}
-
-	return config.Parse(string(content))
-}
-
-func loadEnv() (bool, error) {
-	if medik.EnvFile == "" {
-		return true, nil
-	}
-
-	content, err := os.ReadFile(medik.EnvFile)
-	if err != nil {
-		return false, err
-	}
-
-	return useEnv(string(content))
-}
-
-func useEnv(content string) (bool, error) {
-	env, err := parse.ParseEnvFile(content)
-	if err != nil {
-		return false, err
-	}
-
-	for k, v := range env {
-		err := os.Setenv(k, v)
-		if err != nil {
-			return false, err
-		}
-	}
-
-	return true, nil
-}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for problems without running any exam",
+	Long: "Parses every exam of the config file, including the ones of every protocol, and reports all the problems found.\n" +
+		"Unknown fields are errors and fields not used by an exam are warnings. Neither is reported if strict decoding is disabled.\n" +
+		"Exits with status 1 if any error is found, so it can be used in pre-commit hooks",
+	Args: cobra.NoArgs,
+	Run:  validate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func validate(cmd *cobra.Command, args []string) {
+	problems, err := options.Validate()
+	if err != nil {
+		fmt.Printf("Error loading config: %s\n", err)
+		os.Exit(1)
+	}
+
+	if problems == nil {
+		fmt.Printf("%v is valid\n", options.ConfigFile)
+		return
+	}
+
+	for _, w := range problems.Warnings {
+		fmt.Printf("%v:%v (warning)\n", options.ConfigFile, w)
+	}
+
+	for _, e := range problems.Errors {
+		fmt.Printf("%v:%v\n", options.ConfigFile, e)
+	}
+
+	if len(problems.Errors) > 0 {
+		os.Exit(1)
+	}
+
+	fmt.Printf("%v is valid\n", options.ConfigFile)
+}
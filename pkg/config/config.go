@@ -1,36 +1,346 @@
 package config
 
-import "gopkg.in/yaml.v3"
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
 
 type Medik struct {
 	Exams     []Exam              `yaml:"exams,omitempty"`
 	Protocols map[string]Protocol `yaml:"protocols,omitempty"`
+	Jobs      int                 `yaml:"jobs,omitempty"`
+	Timeout   string              `yaml:"timeout,omitempty"`
+	// The executables that run the exams of a category, like `acme: ./plugins/acme`
+	Plugins map[string]string `yaml:"plugins,omitempty"`
+	// Strict decoding rejects unknown fields and warns about fields not used by an exam. Defaults to true
+	Strict *bool `yaml:"strict,omitempty"`
+	// Env files whose variables are examined, on top of the process environment. Later files win
+	EnvFiles []string `yaml:"env-files,omitempty"`
+	// Patterns like `*_TOKEN` of the variables whose values are hidden in reports. Defaults to DefaultSecrets
+	Secrets []string `yaml:"secrets,omitempty"`
+	// Shows the values of secret variables in reports. It can't be set in the config file
+	ShowSecrets bool `yaml:"-"`
+	// The directory of the config file, which relative paths of the config, like the ones of plugins and
+	// env files, are resolved against. It's empty for configs not read from a file, which use the working directory
+	Dir string `yaml:"-"`
+
+	// The root YAML node of the config. It's nil for configs not read from a file
+	Node *yaml.Node `yaml:"-"`
+}
+
+// Returns if strict decoding is enabled, which is the default
+func (m *Medik) IsStrict() bool {
+	return m.Strict == nil || *m.Strict
+}
+
+// The patterns of the secret variables when the config doesn't set `secrets`
+var DefaultSecrets = []string{"*_KEY", "*_TOKEN", "*_SECRET", "*PASSWORD*"}
+
+// Returns the patterns of the secret variables, which are DefaultSecrets unless `secrets` is set
+// An empty `secrets: []` disables the patterns
+func (m *Medik) SecretPatterns() []string {
+	if m.Secrets == nil {
+		return DefaultSecrets
+	}
+
+	return m.Secrets
 }
 
 type Protocol struct {
 	Exams []Exam `yaml:"exams,omitempty"`
+	// Env files examined only by the exams of the protocol, on top of the top-level ones
+	EnvFiles []string `yaml:"env-files,omitempty"`
 }
 
+// The fields common to every exam
+// The other fields are specific to each exam type, which decodes them from Node using Decode
 type Exam struct {
-	Type     string      `yaml:"exam"`
-	Level    string      `yaml:"level,omitempty"`
-	Vars     []string    `yaml:"vars,omitempty"`
-	Paths    []string    `yaml:"paths,omitempty"`
-	Options  []string    `yaml:"options,omitempty"`
-	Regex    string      `yaml:"regex,omitempty"`
-	Min      interface{} `yaml:"min,omitempty"`
-	Max      interface{} `yaml:"max,omitempty"`
-	Protocol string      `yaml:"protocol,omitempty"`
-	Exists   bool        `yaml:"exists,omitempty"`
+	Type    string `yaml:"exam"`
+	Level   string `yaml:"level,omitempty"`
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// The YAML node the exam was decoded from, including its specific fields. It's nil for empty exams
+	Node *yaml.Node `yaml:"-"`
+	// The directory of the config file the exam was declared in. See Path
+	Dir string `yaml:"-"`
+}
+
+// Creates an exam of type `ty` whose specific fields are the YAML encoding of `options`, as if it was
+// read from a config file. Useful to build configs in code. Panics if `options` can't be encoded
+func NewExam(ty string, options interface{}) Exam {
+	node := &yaml.Node{}
+	if err := node.Encode(options); err != nil {
+		panic(err)
+	}
+
+	if node.Kind != yaml.MappingNode {
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "exam"}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ty}
+	node.Content = append([]*yaml.Node{key, value}, node.Content...)
+
+	return Exam{Type: ty, Node: node}
+}
+
+// Decodes the fields specific to the exam type into `options`, which should be a pointer to a struct
+// Does nothing if the exam has no YAML node
+func (e *Exam) Decode(options interface{}) error {
+	if e.Node == nil {
+		return nil
+	}
+
+	err := e.Node.Decode(options)
+
+	var typeErr *yaml.TypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &typeErr):
+		return &DecodeError{Exam: e.Type, Errors: typeErr.Errors}
+	default:
+		return &DecodeError{Exam: e.Type, Errors: []string{err.Error()}}
+	}
+}
+
+// An error to describe fields of an exam that couldn't be decoded, like a string where a number is expected
+type DecodeError struct {
+	Exam   string
+	Errors []string
+}
+
+func (e *DecodeError) Error() string {
+	return "invalid fields in exam " + e.Exam + ": " + strings.Join(e.Errors, "; ")
+}
+
+func (e *Exam) UnmarshalYAML(node *yaml.Node) error {
+	// A type without methods, so decoding it doesn't call UnmarshalYAML again
+	type plain Exam
+
+	if err := node.Decode((*plain)(e)); err != nil {
+		return err
+	}
+
+	e.Node = node
+
+	return nil
+}
+
+// Resolves a path set in a field of the exam against the directory of its config file, like a template
+// Empty and absolute paths are kept as they are, and so are relative ones if Dir is empty
+func (e *Exam) Path(path string) string {
+	return ResolvePath(e.Dir, path)
+}
+
+// Resolves `path` against `dir`. Empty and absolute paths are kept as they are, and so are relative ones if
+// `dir` is empty, so they're relative to the working directory
+func ResolvePath(dir, path string) string {
+	if dir == "" || path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+// Returns the line and column where the exam is declared, or 0, 0 if unknown
+func (e *Exam) Position() (int, int) {
+	if e.Node == nil {
+		return 0, 0
+	}
+
+	return e.Node.Line, e.Node.Column
+}
+
+// Returns the line and column of a field of the exam. Nested fields are separated by dots, like `cmd.run`
+// Falls back to the position of the exam if the field isn't set
+func (e *Exam) FieldPosition(field string) (int, int) {
+	node := e.Node
+
+	for _, key := range strings.Split(field, ".") {
+		node = mappingValue(node, key)
+		if node == nil {
+			return e.Position()
+		}
+	}
+
+	return node.Line, node.Column
+}
+
+// Returns the value of a key in a mapping node, or nil if it's not there
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
 }
 
 // Given the contents of a Medik configuration file, parse it and return a config.Medik object
 func Parse(content string) (*Medik, error) {
+	var root yaml.Node
+	err := yaml.Unmarshal([]byte(content), &root)
+	if err != nil {
+		return nil, err
+	}
+
 	var m Medik
-	err := yaml.Unmarshal([]byte(content), &m)
+	if len(root.Content) == 0 {
+		return &m, nil
+	}
+
+	err = root.Decode(&m)
 	if err != nil {
 		return nil, err
 	}
 
+	// Skip the document node
+	m.Node = root.Content[0]
+
 	return &m, nil
 }
+
+// Returns the YAML node of a protocol, or nil if unknown
+func (m *Medik) ProtocolNode(name string) *yaml.Node {
+	return mappingValue(mappingValue(m.Node, "protocols"), name)
+}
+
+// Returns the keys of a mapping node and the nodes of the keys themselves, in order
+func MappingKeys(node *yaml.Node) []*yaml.Node {
+	keys := []*yaml.Node{}
+
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i])
+	}
+
+	return keys
+}
+
+// Returns the keys of the YAML fields of a struct type, following the `yaml` tags
+// The keys of inlined structs are included
+func YAMLKeys(t reflect.Type) []string {
+	keys := []string{}
+
+	for _, field := range yamlFields(t) {
+		keys = append(keys, field.key)
+	}
+
+	return keys
+}
+
+// Same as YAMLKeys, but the keys of fields that are structs, or pointers to them, are followed by the paths
+// of their own keys, like `cmd` and `cmd.exit-code`
+func YAMLPaths(t reflect.Type) []string {
+	return yamlPaths(t, "")
+}
+
+func yamlPaths(t reflect.Type, prefix string) []string {
+	paths := []string{}
+
+	for _, field := range yamlFields(t) {
+		paths = append(paths, prefix+field.key)
+
+		if nested := structType(field.t); nested != nil {
+			paths = append(paths, yamlPaths(nested, prefix+field.key+".")...)
+		}
+	}
+
+	return paths
+}
+
+// A key of a YAML mapping that doesn't match any field of the struct it's decoded into
+// Path is the path to the key, like `cmd.exit-code`, and Known are the paths of the keys accepted next to it
+type UnknownKey struct {
+	Path  string
+	Node  *yaml.Node
+	Known []string
+}
+
+// Returns the keys of the mapping `node` that don't match a YAML field of the struct type `t`
+// Fields that are structs, or pointers to them, are checked recursively
+func UnknownKeys(node *yaml.Node, t reflect.Type) []UnknownKey {
+	return unknownKeys(node, t, "")
+}
+
+func unknownKeys(node *yaml.Node, t reflect.Type, prefix string) []UnknownKey {
+	unknown := []UnknownKey{}
+	fields := yamlFields(t)
+
+	known := make([]string, len(fields))
+	for i, field := range fields {
+		known[i] = prefix + field.key
+	}
+
+	for _, key := range MappingKeys(node) {
+		i := slices.IndexFunc(fields, func(f yamlField) bool { return f.key == key.Value })
+		if i < 0 {
+			unknown = append(unknown, UnknownKey{Path: prefix + key.Value, Node: key, Known: known})
+			continue
+		}
+
+		if nested := structType(fields[i].t); nested != nil {
+			unknown = append(unknown, unknownKeys(mappingValue(node, key.Value), nested, prefix+key.Value+".")...)
+		}
+	}
+
+	return unknown
+}
+
+type yamlField struct {
+	key string
+	t   reflect.Type
+}
+
+func yamlFields(t reflect.Type) []yamlField {
+	fields := []yamlField{}
+
+	t = structType(t)
+	if t == nil {
+		return fields
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, flags, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+
+		switch {
+		case name == "-" || !field.IsExported():
+		case strings.Contains(flags, "inline"):
+			fields = append(fields, yamlFields(field.Type)...)
+		case name == "":
+			fields = append(fields, yamlField{strings.ToLower(field.Name), field.Type})
+		default:
+			fields = append(fields, yamlField{name, field.Type})
+		}
+	}
+
+	return fields
+}
+
+// Returns the struct type of `t`, dereferencing pointers, or nil if it isn't a struct
+func structType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
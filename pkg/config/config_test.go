@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestParseConfigFile(t *testing.T) {
 	cfg := `
@@ -30,3 +33,136 @@ vitals:
 		t.Errorf("expected error, got nil")
 	}
 }
+
+func TestParseConfigPositions(t *testing.T) {
+	cfg := `
+exams:
+  - exam: env.is-set
+    vars:
+      - FOO
+protocols:
+  release:
+    exams:
+      - exam: cmd.custom
+        cmd:
+          run: "true"
+`
+
+	m, err := Parse(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if line, col := m.Exams[0].Position(); line != 3 || col != 5 {
+		t.Errorf("expected exam at 3:5, got %v:%v", line, col)
+	}
+
+	if line, col := m.Exams[0].FieldPosition("vars"); line != 5 || col != 7 {
+		t.Errorf("expected vars at 5:7, got %v:%v", line, col)
+	}
+
+	exam := m.Protocols["release"].Exams[0]
+
+	if line, col := exam.FieldPosition("cmd.run"); line != 11 || col != 16 {
+		t.Errorf("expected cmd.run at 11:16, got %v:%v", line, col)
+	}
+
+	if line, col := exam.FieldPosition("cmd.timeout"); line != 9 || col != 9 {
+		t.Errorf("expected missing field to fall back to 9:9, got %v:%v", line, col)
+	}
+
+	var options struct {
+		Cmd struct {
+			Run string `yaml:"run"`
+		} `yaml:"cmd"`
+	}
+
+	if err := exam.Decode(&options); err != nil || options.Cmd.Run != "true" {
+		t.Errorf("expected cmd.run to be decoded, got %v (%v)", options.Cmd.Run, err)
+	}
+}
+
+type testOptions struct {
+	Vars []string `yaml:"vars"`
+	Min  *int     `yaml:"min"`
+	Cmd  *struct {
+		Run string `yaml:"run"`
+	} `yaml:"cmd"`
+}
+
+func TestNewExam(t *testing.T) {
+	min := 3
+	exam := NewExam("test.exam", testOptions{Vars: []string{"FOO"}, Min: &min})
+
+	var options testOptions
+	if err := exam.Decode(&options); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if exam.Type != "test.exam" || len(options.Vars) != 1 || *options.Min != 3 {
+		t.Errorf("unexpected exam %v with options %v", exam.Type, options)
+	}
+}
+
+func TestExamPath(t *testing.T) {
+	exam := Exam{Type: "test.exam", Dir: "/etc/medik"}
+
+	for path, expected := range map[string]string{"": "", ".env": "/etc/medik/.env", "../x": "/etc/x", "/opt/x": "/opt/x"} {
+		if got := exam.Path(path); got != expected {
+			t.Errorf("Path(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+
+	exam.Dir = ""
+	if got := exam.Path(".env"); got != ".env" {
+		t.Errorf("Path(%q) = %q without a directory", ".env", got)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	m, err := Parse(`
+exams:
+  - exam: test.exam
+    min: abc
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var options testOptions
+	err = m.Exams[0].Decode(&options)
+
+	if _, ok := err.(*DecodeError); !ok {
+		t.Errorf("expected a DecodeError, got %v", err)
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	m, err := Parse(`
+exams:
+  - exam: test.exam
+    var: [FOO]
+    cmd:
+      run: "true"
+      dir: /tmp
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unknown := UnknownKeys(m.Exams[0].Node, reflect.TypeOf(testOptions{}))
+
+	paths := []string{}
+	for _, key := range unknown {
+		paths = append(paths, key.Path)
+	}
+
+	// `exam` isn't a field of the options, only of Exam
+	if !reflect.DeepEqual(paths, []string{"exam", "var", "cmd.dir"}) {
+		t.Errorf("unexpected unknown keys %v", paths)
+	}
+
+	if !reflect.DeepEqual(unknown[2].Known, []string{"cmd.run"}) {
+		t.Errorf("unexpected known keys %v", unknown[2].Known)
+	}
+}
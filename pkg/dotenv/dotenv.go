@@ -0,0 +1,408 @@
+// Package dotenv parses env files, following the dotenv syntax used by docker compose
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An error found while parsing an env file. Line starts at 1
+type Error struct {
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Parses the content of an env file:
+//   - Blank lines and lines starting with `#` are ignored, and so is the `export` prefix
+//   - Unquoted values end at the end of the line or at a ` #` comment and are trimmed
+//   - Single quoted values are taken literally, and may span multiple lines
+//   - Double quoted values may span multiple lines and support the `\n`, `\r`, `\t`, `\\`, `\"` and `\$` escapes
+//   - Unquoted and double quoted values expand `$VAR` and `${VAR}`, plus `${VAR:-default}`, `${VAR-default}`,
+//     `${VAR:+alternative}`, `${VAR+alternative}`, `${VAR:?error}` and `${VAR?error}` like a shell does
+//
+// Variables are expanded using the ones defined before them in the file, a variable that isn't defined expands to ""
+func Parse(content string) (map[string]string, error) {
+	return ParseWithLookup(content, nil)
+}
+
+// Same as Parse, but variables not defined in the file are expanded using `lookup`, if not nil
+func ParseWithLookup(content string, lookup func(name string) (string, bool)) (map[string]string, error) {
+	p := &envParser{src: strings.ReplaceAll(content, "\r\n", "\n"), line: 1, env: map[string]string{}, lookup: lookup}
+
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.env, nil
+}
+
+// The state of the parsing of an env file
+type envParser struct {
+	src    string
+	pos    int
+	line   int
+	env    map[string]string
+	lookup func(name string) (string, bool)
+}
+
+func (p *envParser) errorf(line int, format string, args ...interface{}) error {
+	return &Error{Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *envParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *envParser) peek() byte {
+	return p.src[p.pos]
+}
+
+// Moves to the next character, keeping track of the current line
+func (p *envParser) advance() {
+	if p.peek() == '\n' {
+		p.line++
+	}
+	p.pos++
+}
+
+func (p *envParser) skipBlanks() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.advance()
+	}
+}
+
+// Skips everything up to the end of the line, but not the line break
+func (p *envParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.advance()
+	}
+}
+
+func (p *envParser) parse() error {
+	for {
+		p.skipBlanks()
+
+		if p.eof() {
+			return nil
+		}
+
+		switch p.peek() {
+		case '\n':
+			p.advance()
+		case '#':
+			p.skipLine()
+		default:
+			if err := p.parseVariable(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Parses a `KEY=value` assignment, up to the end of its last line
+func (p *envParser) parseVariable() error {
+	line := p.line
+	key := p.parseWord()
+
+	if key == "export" && !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipBlanks()
+		key = p.parseWord()
+	}
+
+	p.skipBlanks()
+
+	if key == "" {
+		return p.errorf(line, "missing variable name before '='")
+	}
+
+	if !isVarName(key) {
+		return p.errorf(line, "invalid variable name '%v'", key)
+	}
+
+	if p.eof() || p.peek() != '=' {
+		return p.errorf(line, "missing '=' after variable '%v'", key)
+	}
+
+	p.advance()
+	p.skipBlanks()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	p.env[key] = value
+
+	return nil
+}
+
+// Reads everything up to a blank, a '=' or the end of the line
+func (p *envParser) parseWord() string {
+	start := p.pos
+
+	for !p.eof() && !strings.ContainsRune(" \t\n=", rune(p.peek())) {
+		p.advance()
+	}
+
+	return p.src[start:p.pos]
+}
+
+func (p *envParser) parseValue() (string, error) {
+	if p.eof() {
+		return "", nil
+	}
+
+	switch p.peek() {
+	case '\'':
+		return p.parseQuoted('\'')
+	case '"':
+		return p.parseQuoted('"')
+	}
+
+	line := p.line
+	start := p.pos
+
+	for !p.eof() && p.peek() != '\n' {
+		// A comment must be separated from the value
+		if p.peek() == '#' && (p.pos == start || p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+		p.advance()
+	}
+
+	raw := strings.TrimRight(p.src[start:p.pos], " \t")
+	p.skipLine()
+
+	return p.expand(raw, false, line)
+}
+
+// Parses a value between `quote` characters, which must be followed only by blanks or a comment
+func (p *envParser) parseQuoted(quote byte) (string, error) {
+	line := p.line
+	p.advance()
+	start := p.pos
+
+	for {
+		if p.eof() {
+			kind := "single"
+			if quote == '"' {
+				kind = "double"
+			}
+
+			return "", p.errorf(line, "unterminated %v quoted value", kind)
+		}
+
+		c := p.peek()
+		if c == quote {
+			break
+		}
+
+		// Skip the escaped character, so an escaped quote doesn't end the value
+		if c == '\\' && quote == '"' && p.pos+1 < len(p.src) {
+			p.advance()
+		}
+		p.advance()
+	}
+
+	raw := p.src[start:p.pos]
+	p.advance()
+
+	p.skipBlanks()
+	if !p.eof() && p.peek() == '#' {
+		p.skipLine()
+	}
+
+	if !p.eof() && p.peek() != '\n' {
+		return "", p.errorf(p.line, "unexpected characters after the closing quote: '%v'", p.src[p.pos:p.lineEnd()])
+	}
+
+	if quote == '\'' {
+		return raw, nil
+	}
+
+	return p.expand(raw, true, line)
+}
+
+func (p *envParser) lineEnd() int {
+	if end := strings.IndexByte(p.src[p.pos:], '\n'); end >= 0 {
+		return p.pos + end
+	}
+
+	return len(p.src)
+}
+
+// Expands the variables referenced in `raw`, and its escapes if `escapes` is set
+// `line` is the line where `raw` starts, used by errors
+func (p *envParser) expand(raw string, escapes bool, line int) (string, error) {
+	var sb strings.Builder
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		switch {
+		case c == '\\' && escapes && i+1 < len(raw):
+			i++
+			switch raw[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '$':
+				sb.WriteByte(raw[i])
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(raw[i])
+			}
+		case c == '$' && i+1 < len(raw) && raw[i+1] == '{':
+			end := matchingBrace(raw, i+2)
+			if end < 0 {
+				return "", p.errorf(line, "unterminated expansion '%v'", raw[i:])
+			}
+
+			value, err := p.expandBraces(raw[i+2:end], escapes, line)
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(value)
+			i = end
+		case c == '$' && i+1 < len(raw) && isVarStart(raw[i+1]):
+			end := i + 1
+			for end < len(raw) && isVarChar(raw[end]) {
+				end++
+			}
+
+			value, _ := p.get(raw[i+1 : end])
+			sb.WriteString(value)
+			i = end - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// Expands the contents of a `${...}` expansion
+func (p *envParser) expandBraces(expr string, escapes bool, line int) (string, error) {
+	end := 0
+	for end < len(expr) && isVarChar(expr[end]) {
+		end++
+	}
+
+	name, op := expr[:end], expr[end:]
+	if !isVarName(name) {
+		return "", p.errorf(line, "invalid expansion '${%v}'", expr)
+	}
+
+	value, set := p.get(name)
+	if op == "" {
+		return value, nil
+	}
+
+	// With a colon, empty variables are handled like unset ones
+	colon := strings.HasPrefix(op, ":")
+	if colon {
+		op = op[1:]
+		set = set && value != ""
+	}
+
+	if op == "" {
+		return "", p.errorf(line, "invalid expansion '${%v}'", expr)
+	}
+
+	word := op[1:]
+
+	switch op[0] {
+	case '-':
+		if set {
+			return value, nil
+		}
+
+		return p.expand(word, escapes, line)
+	case '+':
+		if !set {
+			return "", nil
+		}
+
+		return p.expand(word, escapes, line)
+	case '?':
+		if set {
+			return value, nil
+		}
+
+		message, err := p.expand(word, escapes, line)
+		if err != nil {
+			return "", err
+		}
+
+		if message == "" {
+			message = "is required"
+		}
+
+		return "", p.errorf(line, "variable '%v' %v", name, message)
+	default:
+		return "", p.errorf(line, "invalid expansion '${%v}'", expr)
+	}
+}
+
+// Returns the value of a variable defined before in the file or, if it isn't, by the lookup function
+func (p *envParser) get(name string) (string, bool) {
+	if value, ok := p.env[name]; ok {
+		return value, true
+	}
+
+	if p.lookup != nil {
+		return p.lookup(name)
+	}
+
+	return "", false
+}
+
+// Returns the index of the '}' closing a brace opened right before `start`, or -1 if there isn't one
+func matchingBrace(s string, start int) int {
+	depth := 1
+
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func isVarStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isVarChar(c byte) bool {
+	return isVarStart(c) || '0' <= c && c <= '9'
+}
+
+// Whether `name` is a valid variable name: letters, digits and underscores, not starting with a digit
+func isVarName(name string) bool {
+	if name == "" || !isVarStart(name[0]) {
+		return false
+	}
+
+	for i := range len(name) {
+		if !isVarChar(name[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,182 @@
+package dotenv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := `# comment
+key=value
+key2=value2 # some comment
+key3 = value3
+# just comment
+key4  = 'value4'
+`
+	env, err := Parse(content)
+	expected := map[string]string{
+		"key":  "value",
+		"key2": "value2",
+		"key3": "value3",
+		"key4": "value4",
+	}
+
+	if err != nil {
+		t.Errorf("Parse() failed: %v", err)
+	}
+
+	if len(env) != len(expected) {
+		t.Errorf("Parse() failed: %v", env)
+	}
+
+	for k, v := range env {
+		if expected[k] != v {
+			t.Errorf("Parse() failed: %v: %v, %v", k, v, expected[k])
+		}
+	}
+
+	content = `=value`
+
+	_, err = Parse(content)
+
+	if err == nil {
+		t.Errorf("Parse() failed: %v", content)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	lines := []struct {
+		Line  string
+		Value string
+	}{
+		{"key=value", "value"},
+		{"key = value", "value"},
+		{" key = value ", "value"},
+		{"\tkey = value # comment", "value"},
+		{"key = # comment", ""},
+		{"key = ", ""},
+		{"key=value#not-a-comment", "value#not-a-comment"},
+		{"key=a 'b' \"c\"", "a 'b' \"c\""},
+		{"export key=value", "value"},
+		{"export   key = 'value'", "value"},
+		{"key = 'value' ", "value"},
+		{"key = \"value \" ", "value "},
+		{"key='value' # comment", "value"},
+		{"key=\"value\"# comment", "value"},
+		{"key='# not a comment'", "# not a comment"},
+		{"key='a\\nb $HOME'", "a\\nb $HOME"},
+		{"key=\"a\\nb\\tc\\\\d\\\"e\\$f\\qg\"", "a\nb\tc\\d\"e$f\\qg"},
+		{"key=\"it's\"", "it's"},
+		{"key=\"line1\nline2\"", "line1\nline2"},
+		{"key='line1\nline2'", "line1\nline2"},
+		{"key=a\\nb", "a\\nb"},
+		{"key=$", "$"},
+		{"key=\"100$\"", "100$"},
+	}
+
+	for _, l := range lines {
+		env, err := Parse(l.Line)
+
+		if err != nil || env["key"] != l.Value {
+			t.Errorf("Parse() failed: %q :: %q %v", l.Line, env["key"], err)
+		}
+	}
+}
+
+func TestParseExpansion(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		switch name {
+		case "PROCESS":
+			return "process", true
+		case "EMPTY":
+			return "", true
+		}
+
+		return "", false
+	}
+
+	lines := []struct {
+		Line  string
+		Value string
+	}{
+		{"key=$FOO", "foo"},
+		{"key=${FOO}bar", "foobar"},
+		{"key=$FOO.bar", "foo.bar"},
+		{"key=\"$FOO $PROCESS\"", "foo process"},
+		{"key='$FOO'", "$FOO"},
+		{"key=\"\\$FOO\"", "$FOO"},
+		{"key=$MISSING", ""},
+		{"key=${MISSING:-default}", "default"},
+		{"key=${MISSING-default}", "default"},
+		{"key=${EMPTY:-default}", "default"},
+		{"key=${EMPTY-default}", ""},
+		{"key=${FOO:-default}", "foo"},
+		{"key=${MISSING:-${FOO}-x}", "foo-x"},
+		{"key=${FOO:+alt}", "alt"},
+		{"key=${EMPTY:+alt}", ""},
+		{"key=${EMPTY+alt}", "alt"},
+		{"key=${MISSING+alt}", ""},
+		{"key=${FOO:?is required}", "foo"},
+		{"key=${FOO}", "foo"},
+	}
+
+	for _, l := range lines {
+		env, err := ParseWithLookup("FOO=foo\n"+l.Line, lookup)
+
+		if err != nil || env["key"] != l.Value {
+			t.Errorf("ParseWithLookup() failed: %q :: %q %v", l.Line, env["key"], err)
+		}
+	}
+
+	// Variables are only expanded with the ones defined before them
+	env, err := Parse("A=$B\nB=b\nB=${B}2\nC=$B")
+	if err != nil || env["A"] != "" || env["B"] != "b2" || env["C"] != "b2" {
+		t.Errorf("Parse() failed: %v %v", env, err)
+	}
+
+	// Without a lookup function, the process environment isn't used
+	t.Setenv("MEDIK_PARSE_TEST", "process")
+
+	env, err = Parse("key=${MEDIK_PARSE_TEST:-file}")
+	if err != nil || env["key"] != "file" {
+		t.Errorf("Parse() failed: %v %v", env, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	lines := []struct {
+		Content string
+		Line    int
+		Message string
+	}{
+		{"key", 1, "missing '=' after variable 'key'"},
+		{"=value", 1, "missing variable name before '='"},
+		{"# comment\n\nkey value", 3, "missing '=' after variable 'key'"},
+		{"1key=value", 1, "invalid variable name '1key'"},
+		{"my-key=value", 1, "invalid variable name 'my-key'"},
+		{"a=1\nkey=\"value\n\nb=2", 2, "unterminated double quoted value"},
+		{"key='value", 1, "unterminated single quoted value"},
+		{"key=\"value\" extra", 1, "unexpected characters after the closing quote: 'extra'"},
+		{"key=\"multi\nline\" extra", 2, "unexpected characters after the closing quote: 'extra'"},
+		{"key=${FOO", 1, "unterminated expansion '${FOO'"},
+		{"key=${FOO:}", 1, "invalid expansion '${FOO:}'"},
+		{"key=${FOO!x}", 1, "invalid expansion '${FOO!x}'"},
+		{"key=${}", 1, "invalid expansion '${}'"},
+		{"\nkey=${FOO:?must be set}", 2, "variable 'FOO' must be set"},
+		{"key=${FOO?}", 1, "variable 'FOO' is required"},
+	}
+
+	for _, l := range lines {
+		_, err := Parse(l.Content)
+
+		var fileErr *Error
+		if !errors.As(err, &fileErr) || fileErr.Line != l.Line || fileErr.Message != l.Message {
+			t.Errorf("Parse() failed: %q :: %v", l.Content, err)
+		}
+	}
+
+	_, err := Parse("key")
+	if err == nil || err.Error() != "line 1: missing '=' after variable 'key'" {
+		t.Errorf("Parse() failed: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+// Package environment abstracts where the environment variables examined by medik come from,
+// so an env file can be examined without being loaded into the process environment
+package environment
+
+import (
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// A set of environment variables
+type Source interface {
+	// Returns the value of a variable and whether it's set
+	LookupEnv(name string) (string, bool)
+
+	// Returns every variable as `KEY=value`, like os.Environ
+	Environ() []string
+}
+
+// A Source that knows where its variables come from, like the env file that defines them
+type OriginSource interface {
+	Source
+
+	// Returns where the value of a variable comes from, or an empty string if it's unknown or not set
+	Origin(name string) string
+}
+
+// Returns where the value of a variable of `env` comes from, or an empty string if it's unknown or not set
+func Origin(env Source, name string) string {
+	if o, ok := env.(OriginSource); ok {
+		return o.Origin(name)
+	}
+
+	return ""
+}
+
+// A Source that decides which of its variables are secrets, whose values must not be shown in reports
+type SecretSource interface {
+	Source
+
+	// Returns if the value of a variable must be hidden. `secret` is set when the exam marks it as a secret
+	IsSecret(name string, secret bool) bool
+}
+
+// Returns if the value of a variable of `env` must be hidden in reports
+// Sources that don't implement SecretSource only hide the variables marked as secrets by the exam
+func IsSecret(env Source, name string, secret bool) bool {
+	if s, ok := env.(SecretSource); ok {
+		return s.IsSecret(name, secret)
+	}
+
+	return secret
+}
+
+// Wraps `env` so the variables whose names match one of `patterns` (like `*_KEY`, matched with path.Match
+// ignoring case) are secrets too. If `show` is set, no variable is a secret, not even the ones marked by the exam
+func WithSecrets(env Source, patterns []string, show bool) Source {
+	return &secrets{Source: env, patterns: patterns, show: show}
+}
+
+type secrets struct {
+	Source
+	patterns []string
+	show     bool
+}
+
+func (s *secrets) IsSecret(name string, secret bool) bool {
+	if s.show {
+		return false
+	}
+
+	if secret {
+		return true
+	}
+
+	for _, pattern := range s.patterns {
+		if ok, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(name)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *secrets) Origin(name string) string {
+	return Origin(s.Source, name)
+}
+
+// The environment of the current process
+var Process Source = process{}
+
+type process struct{}
+
+func (process) LookupEnv(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+func (process) Environ() []string {
+	return os.Environ()
+}
+
+// A fixed set of variables, like the ones read from an env file
+type Map map[string]string
+
+func (m Map) LookupEnv(name string) (string, bool) {
+	value, ok := m[name]
+	return value, ok
+}
+
+// Returns the variables sorted by name
+func (m Map) Environ() []string {
+	environ := make([]string, 0, len(m))
+
+	for name, value := range m {
+		environ = append(environ, name+"="+value)
+	}
+	slices.Sort(environ)
+
+	return environ
+}
+
+// The variables of an env file, whose origin is the path of the file
+type File struct {
+	Path string
+	Vars Map
+}
+
+func (f *File) LookupEnv(name string) (string, bool) {
+	return f.Vars.LookupEnv(name)
+}
+
+func (f *File) Environ() []string {
+	return f.Vars.Environ()
+}
+
+func (f *File) Origin(name string) string {
+	if _, ok := f.Vars[name]; ok {
+		return f.Path
+	}
+
+	return ""
+}
+
+// Sources stacked on top of each other, where the later ones override the earlier ones
+// An empty Layered has no variables
+type Layered []Source
+
+func (l Layered) LookupEnv(name string) (string, bool) {
+	for i := len(l) - 1; i >= 0; i-- {
+		if value, ok := l[i].LookupEnv(name); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// Returns the origin of a variable in the topmost layer that sets it
+func (l Layered) Origin(name string) string {
+	for i := len(l) - 1; i >= 0; i-- {
+		if _, ok := l[i].LookupEnv(name); ok {
+			return Origin(l[i], name)
+		}
+	}
+
+	return ""
+}
+
+// Returns the variables of every layer. Overridden variables are only listed once, with their final value,
+// in the position of their first appearance
+func (l Layered) Environ() []string {
+	environ := []string{}
+	index := map[string]int{}
+
+	for _, source := range l {
+		for _, entry := range source.Environ() {
+			name, _, _ := strings.Cut(entry, "=")
+
+			if i, ok := index[name]; ok {
+				environ[i] = entry
+				continue
+			}
+
+			index[name] = len(environ)
+			environ = append(environ, entry)
+		}
+	}
+
+	return environ
+}
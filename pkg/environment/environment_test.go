@@ -0,0 +1,85 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcess(t *testing.T) {
+	t.Setenv("MEDIK_ENVIRONMENT_FOO", "bar")
+
+	value, ok := Process.LookupEnv("MEDIK_ENVIRONMENT_FOO")
+	assert.True(t, ok)
+	assert.Equal(t, "bar", value)
+	assert.Contains(t, Process.Environ(), "MEDIK_ENVIRONMENT_FOO=bar")
+}
+
+func TestMap(t *testing.T) {
+	env := Map{"FOO": "bar", "BAR": ""}
+
+	value, ok := env.LookupEnv("BAR")
+	assert.True(t, ok)
+	assert.Equal(t, "", value)
+
+	_, ok = env.LookupEnv("BAZ")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"BAR=", "FOO=bar"}, env.Environ())
+}
+
+func TestLayered(t *testing.T) {
+	env := Layered{Map{"FOO": "base", "BAR": "base"}, Map{"FOO": "top", "BAZ": "top"}}
+
+	value, _ := env.LookupEnv("FOO")
+	assert.Equal(t, "top", value)
+
+	value, _ = env.LookupEnv("BAR")
+	assert.Equal(t, "base", value)
+
+	_, ok := env.LookupEnv("QUX")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"BAR=base", "FOO=top", "BAZ=top"}, env.Environ())
+
+	_, ok = Layered{}.LookupEnv("FOO")
+	assert.False(t, ok)
+}
+
+func TestOrigin(t *testing.T) {
+	t.Setenv("FOO", "process")
+
+	env := Layered{Process, &File{Path: ".env", Vars: Map{"FOO": "env", "BAR": "env"}}, &File{Path: ".env.local", Vars: Map{"FOO": "local"}}}
+
+	assert.Equal(t, ".env.local", Origin(env, "FOO"))
+	assert.Equal(t, ".env", Origin(env, "BAR"))
+	assert.Equal(t, "", Origin(env, "BAZ"))
+	assert.Equal(t, "", Origin(Process, "FOO"))
+
+	// Variables of the process override the files below them
+	env = Layered{&File{Path: ".env", Vars: Map{"FOO": "env"}}, Process}
+	assert.Equal(t, "", Origin(env, "FOO"))
+}
+
+func TestSecrets(t *testing.T) {
+	env := WithSecrets(&File{Path: ".env", Vars: Map{"API_KEY": "x"}}, []string{"*_KEY", "*password*"}, false)
+
+	assert.True(t, IsSecret(env, "API_KEY", false))
+	assert.True(t, IsSecret(env, "api_key", false))
+	assert.True(t, IsSecret(env, "DB_PASSWORD_FILE", false))
+	assert.False(t, IsSecret(env, "KEY_ID", false))
+	assert.True(t, IsSecret(env, "KEY_ID", true))
+
+	// Wrapping keeps the variables and their origin
+	value, _ := env.LookupEnv("API_KEY")
+	assert.Equal(t, "x", value)
+	assert.Equal(t, ".env", Origin(env, "API_KEY"))
+
+	// Showing secrets overrides everything
+	env = WithSecrets(Map{}, []string{"*_KEY"}, true)
+	assert.False(t, IsSecret(env, "API_KEY", true))
+
+	// Without a policy, only the secrets marked by the exam are hidden
+	assert.False(t, IsSecret(Map{}, "API_KEY", false))
+	assert.True(t, IsSecret(Map{}, "API_KEY", true))
+}
@@ -0,0 +1,193 @@
+package bin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/format"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Function to get a parser for a given type `bin.*`
+// Returns the parser and a boolean indicating if the parser was found
+func GetParser(ty string) (exams.Parser, bool) {
+	return parsers.Get(ty)
+}
+
+// Zero values of the exams of the category
+var all = []exams.Exam{
+	&Exists{},
+	&NotExists{},
+	&Version{},
+}
+
+var parsers = exams.NewParsers(all...)
+
+func init() {
+	exams.MustRegister("bin", GetParser)
+	exams.RegisterFields(all...)
+}
+
+// A report that is returned from a `bin.*` exam
+type BinReport struct {
+	Type     string
+	Lvl      int
+	Statuses []BinStatus
+}
+
+// A status from a part of the execution of a `bin.*` exam
+// Path and Version are empty when the binary wasn't found or its version wasn't detected
+type BinStatus struct {
+	Lvl     int
+	Bin     string
+	Path    string
+	Version string
+	Message string
+}
+
+func (r *BinReport) Level() int {
+	return r.Lvl
+}
+
+func (r *BinReport) Format(verbosity int, noColor bool) (int, string, string) {
+	statuses := ""
+
+	for _, status := range r.Statuses {
+		if status.Lvl >= verbosity {
+			statuses += format.ReportStatus(status.Bin, status.Message, status.Lvl, noColor) + "\n"
+		}
+	}
+
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), statuses
+}
+
+func (r *BinReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Statuses))
+
+	for i, status := range r.Statuses {
+		statuses[i] = exams.Status{Key: status.Bin, Message: status.Message, Level: status.Lvl}
+	}
+
+	return exams.ReportData{Exam: r.Type, Level: r.Lvl, Statuses: statuses}
+}
+
+// Resolves a binary to an executable path
+// If `dirs` is empty the binary is looked up on PATH, otherwise only the given directories are searched
+func Resolve(bin string, dirs []string) (string, error) {
+	if len(dirs) == 0 {
+		return exec.LookPath(bin)
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, bin)
+		if isExecutable(path) {
+			return path, nil
+		}
+	}
+
+	return "", &exec.Error{Name: bin, Err: exec.ErrNotFound}
+}
+
+func isExecutable(path string) bool {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode().IsRegular() && stat.Mode().Perm()&0o111 != 0
+}
+
+// Default implementation for Examinate method of exams.Exam. It resolves every binary in `bins`.
+// Those who are found are validated using the `validate` function, the others are considered invalid.
+func DefaultExaminate(exam string, logLevel int, bins, dirs []string, validate func(bin, path string) BinStatus) *BinReport {
+	statuses := []BinStatus{}
+	level := 0
+
+	for _, bin := range bins {
+		path, err := Resolve(bin, dirs)
+		if err != nil {
+			level = logLevel
+			statuses = append(statuses, notFoundBinStatus(bin, logLevel))
+		} else {
+			status := validate(bin, path)
+
+			if status.Lvl > logLevel {
+				status.Lvl = logLevel
+			}
+
+			if status.Lvl > level {
+				level = status.Lvl
+			}
+
+			statuses = append(statuses, status)
+		}
+	}
+
+	return &BinReport{Type: exam, Lvl: level, Statuses: statuses}
+}
+
+// The fields of the `bin.*` exams that only need a list of binaries and where to look for them
+// Exams with more fields embed it inline
+type BinsFields struct {
+	Bins []string `yaml:"bins"`
+	Dirs []string `yaml:"dirs"`
+}
+
+func (f *BinsFields) Binaries() []string {
+	return f.Bins
+}
+
+// The fields of a `bin.*` exam, which always have a list of binaries
+type Fields interface {
+	Binaries() []string
+}
+
+// Default implementation for Parse method of exams.Exam. It checks the type of the exam, decodes its
+// specific fields into `fields` and checks the list of binaries isn't empty before calling `f`
+func DefaultParse[E exams.Exam](config config.Exam, fields Fields, f func(config config.Exam) (exams.Exam, error)) (exams.Exam, error) {
+	var e E
+	ty := e.Type()
+	if config.Type != ty {
+		return nil, &exams.WrongExamParserError{Source: config.Type, Using: ty}
+	}
+
+	if err := config.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if len(fields.Binaries()) == 0 {
+		return nil, &exams.MissingFieldError{Field: "bins", Exam: ty}
+	}
+
+	return f(config)
+}
+
+func foundBinStatus(bin, path string) BinStatus {
+	return BinStatus{
+		Lvl:     medik.OK,
+		Bin:     bin,
+		Path:    path,
+		Message: "found at " + path,
+	}
+}
+
+func notFoundBinStatus(bin string, level int) BinStatus {
+	return BinStatus{
+		Lvl:     level,
+		Bin:     bin,
+		Message: "not found",
+	}
+}
+
+func invalidBinStatus(bin, path, version string, level int, message string) BinStatus {
+	return BinStatus{
+		Lvl:     level,
+		Bin:     bin,
+		Path:    path,
+		Version: version,
+		Message: message,
+	}
+}
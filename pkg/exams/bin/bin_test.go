@@ -0,0 +1,116 @@
+package bin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates a fake executable in a temporary directory that prints `output` and returns the directory
+func fakeBin(t *testing.T, name, output string) string {
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '" + output + "'\n"
+
+	err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755)
+	assert.Nil(t, err)
+
+	return dir
+}
+
+func TestBinParsersCollection(t *testing.T) {
+	parser, ok := GetParser("bin.inexistent")
+	assert.Nil(t, parser)
+	assert.False(t, ok)
+
+	for _, ty := range []string{"bin.exists", "bin.not-exists", "bin.version"} {
+		parser, ok = GetParser(ty)
+		assert.NotNil(t, parser)
+		assert.True(t, ok)
+	}
+}
+
+func TestBinExists(t *testing.T) {
+	dir := fakeBin(t, "medik-fake", "")
+	exam := &Exists{Bins: []string{"medik-fake", "medik-missing"}, Dirs: []string{dir}, Level: medik.ERROR}
+
+	report := exam.Examinate().(*BinReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, filepath.Join(dir, "medik-fake"), report.Statuses[0].Path)
+	assert.Equal(t, medik.OK, report.Statuses[0].Lvl)
+	assert.Equal(t, medik.ERROR, report.Statuses[1].Lvl)
+
+	// Lookup on PATH
+	t.Setenv("PATH", dir)
+	exam = &Exists{Bins: []string{"medik-fake"}, Level: medik.ERROR}
+	assert.Equal(t, medik.OK, exam.Examinate().Level())
+}
+
+func TestBinNotExists(t *testing.T) {
+	dir := fakeBin(t, "medik-fake", "")
+	exam := &NotExists{Bins: []string{"medik-fake", "medik-missing"}, Dirs: []string{dir}, Level: medik.WARNING}
+
+	report := exam.Examinate().(*BinReport)
+	assert.Equal(t, medik.WARNING, report.Level())
+	assert.Equal(t, medik.WARNING, report.Statuses[0].Lvl)
+	assert.Equal(t, medik.OK, report.Statuses[1].Lvl)
+}
+
+func TestBinVersion(t *testing.T) {
+	dir := fakeBin(t, "medik-fake", "medik-fake version go1.21.3 linux/amd64")
+
+	parse, _ := GetParser("bin.version")
+	exam, err := parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"medik-fake"}, "dirs": []string{dir}, "constraint": ">=1.21 <2"}))
+	assert.Nil(t, err)
+
+	report := exam.Examinate().(*BinReport)
+	assert.Equal(t, medik.OK, report.Level())
+	assert.Equal(t, "1.21.3", report.Statuses[0].Version)
+
+	exam, err = parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"medik-fake"}, "dirs": []string{dir}, "constraint": ">=1.22"}))
+	assert.Nil(t, err)
+
+	report = exam.Examinate().(*BinReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, "1.21.3", report.Statuses[0].Version)
+
+	// Custom regex that doesn't match the output
+	exam, err = parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"medik-fake"}, "dirs": []string{dir}, "constraint": ">=1", "version-regex": `v(\d+)`}))
+	assert.Nil(t, err)
+
+	report = exam.Examinate().(*BinReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Empty(t, report.Statuses[0].Version)
+}
+
+func TestBinVersionParse(t *testing.T) {
+	exam := &Version{}
+
+	// Test invalid type
+	_, err := exam.Parse(config.Exam{Type: "invalid"})
+	assert.NotNil(t, err)
+
+	// Test bins not set
+	_, err = exam.Parse(config.NewExam("bin.version", map[string]interface{}{"constraint": ">=1"}))
+	assert.NotNil(t, err)
+
+	// Test constraint not set
+	_, err = exam.Parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"go"}}))
+	assert.NotNil(t, err)
+
+	// Test invalid constraint
+	_, err = exam.Parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"go"}, "constraint": ">=x"}))
+	assert.NotNil(t, err)
+
+	// Test invalid regex
+	_, err = exam.Parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"go"}, "constraint": ">=1", "version-regex": "["}))
+	assert.NotNil(t, err)
+
+	// Test valid config with defaults
+	parsed, err := exam.Parse(config.NewExam("bin.version", map[string]interface{}{"bins": []string{"go"}, "constraint": ">=1"}))
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultVersionArgs, parsed.(*Version).Args)
+}
@@ -0,0 +1,38 @@
+package bin
+
+import (
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a binary can be found on PATH or in the given directories
+//
+// type: bin.exists,
+// bins: []string,
+// dirs: []string
+type Exists struct {
+	Bins  []string
+	Dirs  []string
+	Level int
+}
+
+func (b *Exists) Type() string {
+	return "bin.exists"
+}
+
+func (b *Exists) Fields() interface{} {
+	return &BinsFields{}
+}
+
+func (b *Exists) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &BinsFields{}
+
+	return DefaultParse[*Exists](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &Exists{fields.Bins, fields.Dirs, medik.LogLevelFromStr(config.Level)}, nil
+	})
+}
+
+func (b *Exists) Examinate() exams.Report {
+	return DefaultExaminate(b.Type(), b.Level, b.Bins, b.Dirs, foundBinStatus)
+}
@@ -0,0 +1,51 @@
+package bin
+
+import (
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a binary can't be found on PATH or in the given directories
+//
+// type: bin.not-exists,
+// bins: []string,
+// dirs: []string
+type NotExists struct {
+	Bins  []string
+	Dirs  []string
+	Level int
+}
+
+func (b *NotExists) Type() string {
+	return "bin.not-exists"
+}
+
+func (b *NotExists) Fields() interface{} {
+	return &BinsFields{}
+}
+
+func (b *NotExists) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &BinsFields{}
+
+	return DefaultParse[*NotExists](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &NotExists{fields.Bins, fields.Dirs, medik.LogLevelFromStr(config.Level)}, nil
+	})
+}
+
+func (b *NotExists) Examinate() exams.Report {
+	statuses := []BinStatus{}
+	level := 0
+
+	for _, bin := range b.Bins {
+		path, err := Resolve(bin, b.Dirs)
+		if err != nil {
+			statuses = append(statuses, BinStatus{Lvl: medik.OK, Bin: bin, Message: "not found"})
+		} else {
+			statuses = append(statuses, invalidBinStatus(bin, path, "", b.Level, "should not exist, but was found at "+path))
+			level = b.Level
+		}
+	}
+
+	return &BinReport{Type: b.Type(), Lvl: level, Statuses: statuses}
+}
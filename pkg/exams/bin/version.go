@@ -0,0 +1,132 @@
+package bin
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/OJarrisonn/medik/pkg/semver"
+)
+
+var (
+	DefaultVersionArgs  = []string{"--version"}
+	DefaultVersionRegex = `(\d+\.\d+(?:\.\d+)?)`
+)
+
+// Check if a binary exists and its version satisfies a semver constraint
+// The version is extracted from the output of running the binary with `version-args` using `version-regex`
+// If the regex has a capture group, the first group is used as the version, otherwise the whole match is used
+//
+// type: bin.version,
+// bins: []string,
+// dirs: []string,
+// version-args: []string (default: [--version]),
+// version-regex: string (default: `(\d+\.\d+(?:\.\d+)?)`),
+// constraint: string
+type Version struct {
+	Bins       []string
+	Dirs       []string
+	Level      int
+	Args       []string
+	Regex      *regexp.Regexp
+	Constraint semver.Constraint
+}
+
+// The fields of a bin.version exam
+type VersionFields struct {
+	BinsFields   `yaml:",inline"`
+	VersionArgs  []string `yaml:"version-args"`
+	VersionRegex string   `yaml:"version-regex"`
+	Constraint   string   `yaml:"constraint"`
+}
+
+func (b *Version) Type() string {
+	return "bin.version"
+}
+
+func (b *Version) Fields() interface{} {
+	return &VersionFields{}
+}
+
+func (b *Version) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &VersionFields{}
+
+	return DefaultParse[*Version](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		if fields.Constraint == "" {
+			return nil, &exams.MissingFieldError{Field: "constraint", Exam: b.Type()}
+		}
+
+		constraint, err := semver.ParseConstraint(fields.Constraint)
+		if err != nil {
+			return nil, &exams.FieldValueError{Field: "constraint", Exam: b.Type(), Value: fields.Constraint, Message: err.Error()}
+		}
+
+		rawRegex := fields.VersionRegex
+		if rawRegex == "" {
+			rawRegex = DefaultVersionRegex
+		}
+
+		regex, err := regexp.Compile(rawRegex)
+		if err != nil {
+			return nil, &exams.FieldValueError{Field: "version-regex", Exam: b.Type(), Value: rawRegex, Message: err.Error()}
+		}
+
+		args := fields.VersionArgs
+		if len(args) == 0 {
+			args = DefaultVersionArgs
+		}
+
+		return &Version{fields.Bins, fields.Dirs, medik.LogLevelFromStr(config.Level), args, regex, constraint}, nil
+	})
+}
+
+func (b *Version) Examinate() exams.Report {
+	return b.ExaminateContext(context.Background())
+}
+
+func (b *Version) ExaminateContext(ctx context.Context) exams.Report {
+	return DefaultExaminate(b.Type(), b.Level, b.Bins, b.Dirs, func(bin, path string) BinStatus {
+		command := exec.CommandContext(ctx, path, b.Args...)
+		command.WaitDelay = time.Second
+
+		output, err := command.CombinedOutput()
+		if err != nil {
+			return invalidBinStatus(bin, path, "", b.Level, fmt.Sprintf("failed to run `%v %v`: %v", path, strings.Join(b.Args, " "), err))
+		}
+
+		raw, ok := b.extractVersion(string(output))
+		if !ok {
+			return invalidBinStatus(bin, path, "", b.Level, fmt.Sprintf("found at %v, but no version matching %v was found in its output", path, b.Regex))
+		}
+
+		version, err := semver.Parse(raw)
+		if err != nil {
+			return invalidBinStatus(bin, path, raw, b.Level, fmt.Sprintf("found at %v, but %v", path, err))
+		}
+
+		if !b.Constraint.Check(version) {
+			return invalidBinStatus(bin, path, raw, b.Level, fmt.Sprintf("found at %v with version %v, but it should satisfy %v", path, raw, b.Constraint))
+		}
+
+		return BinStatus{Lvl: medik.OK, Bin: bin, Path: path, Version: raw, Message: fmt.Sprintf("found at %v with version %v", path, raw)}
+	})
+}
+
+func (b *Version) extractVersion(output string) (string, bool) {
+	match := b.Regex.FindStringSubmatch(output)
+
+	switch {
+	case match == nil:
+		return "", false
+	case len(match) > 1:
+		return match[1], true
+	default:
+		return match[0], true
+	}
+}
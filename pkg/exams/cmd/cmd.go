@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/format"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// The maximum amount of bytes of stdout/stderr shown in a report
+const MaxOutputLength = 512
+
+// Function to get a parser for a given type `cmd.*`
+// Returns the parser and a boolean indicating if the parser was found
+func GetParser(ty string) (exams.Parser, bool) {
+	return parsers.Get(ty)
+}
+
+// Zero values of the exams of the category
+var all = []exams.Exam{
+	&Custom{},
+}
+
+var parsers = exams.NewParsers(all...)
+
+func init() {
+	exams.MustRegister("cmd", GetParser)
+	exams.RegisterFields(all...)
+}
+
+// A report that is returned from a `cmd.*` exam
+// Stdout and Stderr hold the (truncated) output of the command, shown at the highest verbosity when it fails
+type CmdReport struct {
+	Type     string
+	Lvl      int
+	Statuses []CmdStatus
+	Stdout   string
+	Stderr   string
+}
+
+// A status from one of the validations of a `cmd.*` exam
+type CmdStatus struct {
+	Lvl     int
+	Cmd     string
+	Message string
+}
+
+func (r *CmdReport) Level() int {
+	return r.Lvl
+}
+
+func (r *CmdReport) Format(verbosity int, noColor bool) (int, string, string) {
+	statuses := ""
+
+	for _, status := range r.Statuses {
+		if status.Lvl >= verbosity {
+			statuses += format.ReportStatus(status.Cmd, status.Message, status.Lvl, noColor) + "\n"
+		}
+	}
+
+	if r.Lvl > medik.OK && verbosity <= medik.OK {
+		if r.Stdout != "" {
+			statuses += format.ReportStatus("stdout", r.Stdout, r.Lvl, noColor) + "\n"
+		}
+
+		if r.Stderr != "" {
+			statuses += format.ReportStatus("stderr", r.Stderr, r.Lvl, noColor) + "\n"
+		}
+	}
+
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), statuses
+}
+
+func (r *CmdReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Statuses))
+
+	for i, status := range r.Statuses {
+		statuses[i] = exams.Status{Key: status.Cmd, Message: status.Message, Level: status.Lvl}
+	}
+
+	return exams.ReportData{Exam: r.Type, Level: r.Lvl, Statuses: statuses}
+}
+
+// Truncates the output of a command to MaxOutputLength bytes
+func truncate(output string) string {
+	output = strings.TrimSpace(output)
+
+	if len(output) <= MaxOutputLength {
+		return output
+	}
+
+	return output[:MaxOutputLength] + "... (truncated)"
+}
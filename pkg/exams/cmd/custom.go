@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// The timeout used when a command doesn't set one
+const DefaultTimeout = 30 * time.Second
+
+// Run a custom command in the shell and validate its exit code and output
+// If `exit-code` isn't set, the command is expected to exit with 0. A relative `dir` is resolved against the
+// directory of the config file, and the command runs in the working directory if it isn't set
+//
+// type: cmd.custom,
+// cmd: {
+// run: string,
+// dir: string,
+// env: map[string]string,
+// timeout: duration (default: 30s),
+// exit-code: int,
+// stdout-contains, stdout-not-contains, stdout-regex: string,
+// stderr-contains, stderr-not-contains, stderr-regex: string
+// }
+type Custom struct {
+	Run      string
+	Dir      string
+	Env      []string
+	Timeout  time.Duration
+	ExitCode int
+	Stdout   OutputValidation
+	Stderr   OutputValidation
+	Level    int
+}
+
+// The validations applied to one of the outputs of a command. Empty fields are not checked
+type OutputValidation struct {
+	Contains    string
+	NotContains string
+	Regex       *regexp.Regexp
+}
+
+func (c *Custom) Type() string {
+	return "cmd.custom"
+}
+
+// The fields of a cmd.custom exam
+type CustomFields struct {
+	Cmd *Command `yaml:"cmd"`
+}
+
+// The command to be run by a `cmd.custom` exam and the validations applied to its result
+type Command struct {
+	Run               string            `yaml:"run"`
+	Dir               string            `yaml:"dir"`
+	Env               map[string]string `yaml:"env"`
+	Timeout           string            `yaml:"timeout"`
+	ExitCode          *int              `yaml:"exit-code"`
+	StdoutContains    string            `yaml:"stdout-contains"`
+	StdoutNotContains string            `yaml:"stdout-not-contains"`
+	StdoutRegex       string            `yaml:"stdout-regex"`
+	StderrContains    string            `yaml:"stderr-contains"`
+	StderrNotContains string            `yaml:"stderr-not-contains"`
+	StderrRegex       string            `yaml:"stderr-regex"`
+}
+
+func (c *Custom) Fields() interface{} {
+	return &CustomFields{}
+}
+
+func (c *Custom) Parse(conf config.Exam) (exams.Exam, error) {
+	if conf.Type != c.Type() {
+		return nil, &exams.WrongExamParserError{Source: conf.Type, Using: c.Type()}
+	}
+
+	fields := &CustomFields{}
+	if err := conf.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if fields.Cmd == nil {
+		return nil, &exams.MissingFieldError{Field: "cmd", Exam: c.Type()}
+	}
+
+	command := fields.Cmd
+
+	if strings.TrimSpace(command.Run) == "" {
+		return nil, &exams.MissingFieldError{Field: "cmd.run", Exam: c.Type()}
+	}
+
+	timeout := DefaultTimeout
+	if command.Timeout != "" {
+		parsed, err := time.ParseDuration(command.Timeout)
+		if err != nil || parsed <= 0 {
+			return nil, &exams.FieldValueError{Field: "cmd.timeout", Exam: c.Type(), Value: command.Timeout, Message: "expected a positive duration like 10s"}
+		}
+		timeout = parsed
+	}
+
+	exitCode := 0
+	if command.ExitCode != nil {
+		exitCode = *command.ExitCode
+	}
+
+	env := []string{}
+	for k, v := range command.Env {
+		env = append(env, k+"="+v)
+	}
+
+	stdout, err := c.parseOutputValidation("stdout", command.StdoutContains, command.StdoutNotContains, command.StdoutRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	stderr, err := c.parseOutputValidation("stderr", command.StderrContains, command.StderrNotContains, command.StderrRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Custom{
+		Run:      command.Run,
+		Dir:      conf.Path(command.Dir),
+		Env:      env,
+		Timeout:  timeout,
+		ExitCode: exitCode,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		Level:    medik.LogLevelFromStr(conf.Level),
+	}, nil
+}
+
+func (c *Custom) parseOutputValidation(output, contains, notContains, rawRegex string) (OutputValidation, error) {
+	validation := OutputValidation{Contains: contains, NotContains: notContains}
+
+	if rawRegex != "" {
+		regex, err := regexp.Compile(rawRegex)
+		if err != nil {
+			return validation, &exams.FieldValueError{Field: "cmd." + output + "-regex", Exam: c.Type(), Value: rawRegex, Message: err.Error()}
+		}
+		validation.Regex = regex
+	}
+
+	return validation, nil
+}
+
+func (c *Custom) Examinate() exams.Report {
+	return c.ExaminateContext(context.Background())
+}
+
+func (c *Custom) ExaminateContext(ctx context.Context) exams.Report {
+	return c.ExaminateEnv(ctx, environment.Process)
+}
+
+// Runs the command with the variables of `env` plus the ones set by the exam
+func (c *Custom) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, "sh", "-c", c.Run)
+	command.Dir = c.Dir
+	command.Env = append(env.Environ(), c.Env...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	// Don't wait forever for children of the shell still holding the output open after a timeout
+	command.WaitDelay = time.Second
+
+	err := command.Run()
+
+	report := &CmdReport{Type: c.Type(), Stdout: truncate(stdout.String()), Stderr: truncate(stderr.String())}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		report.Statuses = append(report.Statuses, c.failed(fmt.Sprintf("timed out after %v", c.Timeout)))
+	case err != nil && !errors.As(err, &exitErr):
+		report.Statuses = append(report.Statuses, c.failed("failed to run: "+err.Error()))
+	default:
+		code := command.ProcessState.ExitCode()
+		if code == c.ExitCode {
+			report.Statuses = append(report.Statuses, c.passed(fmt.Sprintf("exited with code %v", code)))
+		} else {
+			report.Statuses = append(report.Statuses, c.failed(fmt.Sprintf("exited with code %v, expected %v", code, c.ExitCode)))
+		}
+
+		report.Statuses = append(report.Statuses, c.validateOutput("stdout", stdout.String(), c.Stdout)...)
+		report.Statuses = append(report.Statuses, c.validateOutput("stderr", stderr.String(), c.Stderr)...)
+	}
+
+	for _, status := range report.Statuses {
+		if status.Lvl > report.Lvl {
+			report.Lvl = status.Lvl
+		}
+	}
+
+	return report
+}
+
+func (c *Custom) validateOutput(name, output string, validation OutputValidation) []CmdStatus {
+	statuses := []CmdStatus{}
+
+	if validation.Contains != "" {
+		if strings.Contains(output, validation.Contains) {
+			statuses = append(statuses, c.passed(fmt.Sprintf("%v contains '%v'", name, validation.Contains)))
+		} else {
+			statuses = append(statuses, c.failed(fmt.Sprintf("%v should contain '%v'", name, validation.Contains)))
+		}
+	}
+
+	if validation.NotContains != "" {
+		if !strings.Contains(output, validation.NotContains) {
+			statuses = append(statuses, c.passed(fmt.Sprintf("%v doesn't contain '%v'", name, validation.NotContains)))
+		} else {
+			statuses = append(statuses, c.failed(fmt.Sprintf("%v should not contain '%v'", name, validation.NotContains)))
+		}
+	}
+
+	if validation.Regex != nil {
+		if validation.Regex.MatchString(output) {
+			statuses = append(statuses, c.passed(fmt.Sprintf("%v matches regex %v", name, validation.Regex)))
+		} else {
+			statuses = append(statuses, c.failed(fmt.Sprintf("%v should match regex %v", name, validation.Regex)))
+		}
+	}
+
+	return statuses
+}
+
+func (c *Custom) passed(message string) CmdStatus {
+	return CmdStatus{Lvl: medik.OK, Cmd: c.Run, Message: message}
+}
+
+func (c *Custom) failed(message string) CmdStatus {
+	return CmdStatus{Lvl: c.Level, Cmd: c.Run, Message: message}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseCustom(t *testing.T, command Command) *Custom {
+	exam, err := (&Custom{}).Parse(config.NewExam("cmd.custom", CustomFields{Cmd: &command}))
+	assert.Nil(t, err)
+
+	return exam.(*Custom)
+}
+
+func TestCmdCustomParse(t *testing.T) {
+	exam := &Custom{}
+
+	// Test invalid type
+	_, err := exam.Parse(config.Exam{Type: "invalid"})
+	assert.NotNil(t, err)
+
+	// Test cmd not set
+	_, err = exam.Parse(config.Exam{Type: "cmd.custom"})
+	assert.NotNil(t, err)
+
+	// Test run not set
+	_, err = exam.Parse(config.NewExam("cmd.custom", CustomFields{Cmd: &Command{}}))
+	assert.NotNil(t, err)
+
+	// Test invalid timeout
+	_, err = exam.Parse(config.NewExam("cmd.custom", CustomFields{Cmd: &Command{Run: "true", Timeout: "soon"}}))
+	assert.NotNil(t, err)
+
+	// Test invalid regex
+	_, err = exam.Parse(config.NewExam("cmd.custom", CustomFields{Cmd: &Command{Run: "true", StderrRegex: "["}}))
+	assert.NotNil(t, err)
+
+	// Test valid config with defaults
+	parsed := parseCustom(t, Command{Run: "true"})
+	assert.Equal(t, DefaultTimeout, parsed.Timeout)
+	assert.Equal(t, 0, parsed.ExitCode)
+	assert.Equal(t, medik.ERROR, parsed.Level)
+}
+
+func TestCmdCustomExitCode(t *testing.T) {
+	report := parseCustom(t, Command{Run: "exit 0"}).Examinate()
+	assert.Equal(t, medik.OK, report.Level())
+
+	report = parseCustom(t, Command{Run: "exit 3"}).Examinate()
+	assert.Equal(t, medik.ERROR, report.Level())
+
+	code := 3
+	report = parseCustom(t, Command{Run: "exit 3", ExitCode: &code}).Examinate()
+	assert.Equal(t, medik.OK, report.Level())
+}
+
+func TestCmdCustomOutput(t *testing.T) {
+	command := Command{
+		Run:               "printf 'hello %s\\n' world; echo oops >&2",
+		StdoutContains:    "hello",
+		StdoutNotContains: "bye",
+		StdoutRegex:       "^hello",
+		StderrContains:    "oops",
+	}
+
+	report := parseCustom(t, command).Examinate()
+	assert.Equal(t, medik.OK, report.Level())
+	assert.Len(t, report.Data().Statuses, 5)
+
+	command.StdoutContains = "bye"
+	report = parseCustom(t, command).Examinate()
+	assert.Equal(t, medik.ERROR, report.Level())
+
+	// Output is only shown at the highest verbosity
+	_, _, body := report.Format(medik.WARNING, true)
+	assert.NotContains(t, body, "hello world")
+	_, _, body = report.Format(medik.OK, true)
+	assert.Contains(t, body, "hello world")
+	assert.Contains(t, body, "oops")
+}
+
+func TestCmdCustomDirAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	command := Command{
+		Run:            `echo "$MEDIK_CMD_TEST" && pwd`,
+		Dir:            dir,
+		Env:            map[string]string{"MEDIK_CMD_TEST": "from-env"},
+		StdoutContains: "from-env\n" + dir,
+	}
+
+	report := parseCustom(t, command).Examinate()
+	assert.Equal(t, medik.OK, report.Level())
+}
+
+func TestCmdCustomSourceEnv(t *testing.T) {
+	exam := parseCustom(t, Command{Run: `echo "$MEDIK_CMD_SOURCE-$MEDIK_CMD_TEST"`, Env: map[string]string{"MEDIK_CMD_TEST": "exam"}, StdoutContains: "source-exam"})
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"MEDIK_CMD_SOURCE": "source", "MEDIK_CMD_TEST": "source"})
+	assert.Equal(t, medik.OK, report.Level())
+}
+
+func TestCmdCustomTimeout(t *testing.T) {
+	report := parseCustom(t, Command{Run: "sleep 5", Timeout: "100ms"}).Examinate()
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Contains(t, report.Data().Statuses[0].Message, "timed out")
+}
+
+func TestTruncate(t *testing.T) {
+	long := make([]byte, MaxOutputLength+10)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	assert.Equal(t, "short", truncate(" short\n"))
+	assert.Len(t, truncate(string(long)), MaxOutputLength+len("... (truncated)"))
+}
@@ -0,0 +1,120 @@
+package env
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to base64 data, optionally with a decoded size within a range
+// `encoding` selects one of `std`, `url`, `raw-std` and `raw-url` (without padding). By default, any of them is valid
+// Min and Max are inclusive sizes like `32` or `1KiB`, and both are optional
+//
+// type: env.base64,
+// vars: []string,
+// encoding: string,
+// min: size,
+// max: size
+type Base64 struct {
+	Vars     []string
+	Level    int
+	Encoding string
+	Range    Range[int64]
+	Secret   bool
+}
+
+// The fields of an env.base64 exam
+type Base64Fields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
+	Encoding    string `yaml:"encoding"`
+}
+
+var base64Encodings = map[string]*base64.Encoding{
+	"std":     base64.StdEncoding,
+	"url":     base64.URLEncoding,
+	"raw-std": base64.RawStdEncoding,
+	"raw-url": base64.RawURLEncoding,
+}
+
+func (r *Base64) Type() string {
+	return "env.base64"
+}
+
+func (r *Base64) Fields() interface{} {
+	return &Base64Fields{}
+}
+
+func (r *Base64) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &Base64Fields{}
+
+	return DefaultParse[*Base64](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		if _, ok := base64Encodings[fields.Encoding]; fields.Encoding != "" && !ok {
+			return nil, &exams.FieldValueError{Field: "encoding", Exam: r.Type(), Value: fields.Encoding, Message: "should be one of std, url, raw-std or raw-url"}
+		}
+
+		bounds, err := parseRange(fields.RangeFields, r.Type(), false, ParseSize)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Base64{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Encoding, bounds, fields.Secret}, nil
+	})
+}
+
+func (r *Base64) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Base64) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		decoded, err := r.decode(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, err.Error())
+		}
+
+		return decodedSizeStatus(name, r.Level, value, secret, r.Range, len(decoded))
+	})
+}
+
+func (r *Base64) decode(value string) ([]byte, error) {
+	if r.Encoding != "" {
+		decoded, err := base64Encodings[r.Encoding].DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("value should be %v base64: %w", r.Encoding, err)
+		}
+
+		return decoded, nil
+	}
+
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(value, "-_") {
+		encoding = base64.URLEncoding
+	}
+
+	if !strings.HasSuffix(value, "=") {
+		encoding = encoding.WithPadding(base64.NoPadding)
+	}
+
+	decoded, err := encoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("value should be base64: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// Returns the status of a value whose decoded size should be within `bounds`
+func decodedSizeStatus(name string, level int, value string, secret bool, bounds Range[int64], size int) EnvStatus {
+	if !bounds.Contains(int64(size)) {
+		return invalidEnvVarStatus(name, level, value, secret, fmt.Sprintf("decoded %v, got %v", bounds.ErrorMessage(FormatSize), FormatSize(int64(size))))
+	}
+
+	return validEnvVarStatus(name)
+}
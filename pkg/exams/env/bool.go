@@ -0,0 +1,108 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is a boolean, like `true`, `yes` or `0`. Values are compared ignoring case
+// `true-values` and `false-values` replace the accepted vocabularies, like `[enabled]` and `[disabled]`
+//
+// type: env.bool,
+// vars: []string,
+// true-values: []string,
+// false-values: []string
+type Bool struct {
+	Vars        []string
+	Level       int
+	TrueValues  []string
+	FalseValues []string
+	Secret      bool
+}
+
+// The fields of an env.bool exam
+type BoolFields struct {
+	VarsFields  `yaml:",inline"`
+	TrueValues  []string `yaml:"true-values"`
+	FalseValues []string `yaml:"false-values"`
+}
+
+// The values accepted by an env.bool exam by default
+var (
+	DefaultTrueValues  = []string{"true", "t", "yes", "y", "on", "1"}
+	DefaultFalseValues = []string{"false", "f", "no", "n", "off", "0"}
+)
+
+func (r *Bool) Type() string {
+	return "env.bool"
+}
+
+func (r *Bool) Fields() interface{} {
+	return &BoolFields{}
+}
+
+func (r *Bool) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &BoolFields{}
+
+	return DefaultParse[*Bool](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		exam := &Bool{fields.Vars, medik.LogLevelFromStr(conf.Level), DefaultTrueValues, DefaultFalseValues, fields.Secret}
+
+		if fields.TrueValues != nil {
+			exam.TrueValues = fields.TrueValues
+		}
+
+		if fields.FalseValues != nil {
+			exam.FalseValues = fields.FalseValues
+		}
+
+		for _, value := range exam.TrueValues {
+			if exam.isFalse(value) {
+				return nil, &exams.FieldValueError{Field: "true-values", Exam: r.Type(), Value: value, Message: "is also a false value"}
+			}
+		}
+
+		return exam, nil
+	})
+}
+
+func (r *Bool) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Bool) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		if !r.isTrue(value) && !r.isFalse(value) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.ErrorMessage())
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+func (r *Bool) ErrorMessage() string {
+	return fmt.Sprintf("value should be one of %v or %v", r.TrueValues, r.FalseValues)
+}
+
+func (r *Bool) isTrue(value string) bool {
+	return containsFold(r.TrueValues, strings.TrimSpace(value))
+}
+
+func (r *Bool) isFalse(value string) bool {
+	return containsFold(r.FalseValues, strings.TrimSpace(value))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package env
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a network in CIDR notation, like `10.0.0.0/8` or `fd00::/8`
+// `family` restricts it to `ipv4` or `ipv6` networks. Networks with host bits set, like `10.0.0.1/8`, are warnings
+//
+// type: env.cidr,
+// vars: []string,
+// family: string
+type Cidr struct {
+	Vars   []string
+	Level  int
+	Family string
+	Secret bool
+}
+
+// The fields of an env.cidr exam
+type CidrFields struct {
+	VarsFields `yaml:",inline"`
+	Family     string `yaml:"family"`
+}
+
+func (r *Cidr) Type() string {
+	return "env.cidr"
+}
+
+func (r *Cidr) Fields() interface{} {
+	return &CidrFields{}
+}
+
+func (r *Cidr) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &CidrFields{}
+
+	return DefaultParse[*Cidr](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		switch fields.Family {
+		case "", "ipv4", "ipv6":
+		default:
+			return nil, &exams.FieldValueError{Field: "family", Exam: r.Type(), Value: fields.Family, Message: "should be ipv4 or ipv6"}
+		}
+
+		return &Cidr{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Family, fields.Secret}, nil
+	})
+}
+
+func (r *Cidr) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Cidr) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a network in CIDR notation, like 10.0.0.0/8")
+		}
+
+		if (r.Family == "ipv4" && !prefix.Addr().Is4()) || (r.Family == "ipv6" && !prefix.Addr().Is6()) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be an "+r.Family+" network")
+		}
+
+		if masked := prefix.Masked(); masked != prefix {
+			// The network is most of the value
+			if secret {
+				return EnvStatus{Lvl: medik.WARNING, Var: name, Message: "has host bits set"}
+			}
+
+			return EnvStatus{Lvl: medik.WARNING, Var: name, Message: "has host bits set, the network is " + masked.String()}
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
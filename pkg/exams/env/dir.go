@@ -1,10 +1,12 @@
 package env
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -17,35 +19,51 @@ type Dir struct {
 	Vars   []string
 	Level  int
 	Exists bool
+	Secret bool
 }
 
 func (r *Dir) Type() string {
 	return "env.dir"
 }
 
+func (r *Dir) Fields() interface{} {
+	return &ExistsFields{}
+}
+
 func (r *Dir) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Dir](conf, func(conf config.Exam) (exams.Exam, error) {
-		return &Dir{conf.Vars, medik.LogLevelFromStr(conf.Level), conf.Exists}, nil
+	fields := &ExistsFields{}
+
+	return DefaultParse[*Dir](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Dir{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Exists, fields.Secret}, nil
 	})
 }
 
 func (r *Dir) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Dir) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		stat, err := os.Stat(value)
 
 		if exists := err == nil && stat.IsDir(); exists != r.Exists {
-			return invalidEnvVarStatus(name, r.Level, value, r.ErrorMessage(err))
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.ErrorMessage(err, secret))
 		}
 
 		return validEnvVarStatus(name)
 	})
 }
 
-func (r *Dir) ErrorMessage(err error) string {
+func (r *Dir) ErrorMessage(err error, secret bool) string {
 	non := "an "
 	if !r.Exists {
 		non = "a non "
 	}
 
+	// The error of os.Stat has the path, which is the value
+	if secret {
+		return fmt.Sprintf("value should point to %vexisting directory", non)
+	}
+
 	return fmt.Sprintf("value should point to %vexisting directory. %v", non, err)
 }
@@ -12,7 +12,7 @@ func TestEnvDirExists(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -21,7 +21,7 @@ func TestEnvDirExists(t *testing.T) {
 	t.Setenv("VAR1", "/invalid/path")
 	t.Setenv("VAR2", "/etc")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -29,7 +29,7 @@ func TestEnvDirExists(t *testing.T) {
 	// Test when environment variables are valid directories
 	t.Setenv("VAR1", "/etc")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -40,7 +40,7 @@ func TestEnvDirNotExists(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -49,7 +49,7 @@ func TestEnvDirNotExists(t *testing.T) {
 	t.Setenv("VAR1", "/etc")
 	t.Setenv("VAR2", "/invalid/path")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -57,7 +57,7 @@ func TestEnvDirNotExists(t *testing.T) {
 	// Test when environment variables are not valid directories
 	t.Setenv("VAR1", "/invalid/path")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
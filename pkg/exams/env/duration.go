@@ -0,0 +1,71 @@
+package env
+
+import (
+	"context"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is a duration like `30s` or `1h30m`, optionally within a range
+// Min and Max are inclusive durations, and both are optional
+//
+// type: env.duration,
+// vars: []string,
+// min: duration,
+// max: duration
+type Duration struct {
+	Vars   []string
+	Level  int
+	Range  Range[time.Duration]
+	Secret bool
+}
+
+// The fields of an env.duration exam
+type DurationFields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
+}
+
+func (r *Duration) Type() string {
+	return "env.duration"
+}
+
+func (r *Duration) Fields() interface{} {
+	return &DurationFields{}
+}
+
+func (r *Duration) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &DurationFields{}
+
+	return DefaultParse[*Duration](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		bounds, err := parseRange(fields.RangeFields, r.Type(), false, time.ParseDuration)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Duration{fields.Vars, medik.LogLevelFromStr(conf.Level), bounds, fields.Secret}, nil
+	})
+}
+
+func (r *Duration) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Duration) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a duration like 30s or 1h30m")
+		}
+
+		if !r.Range.Contains(duration) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.Range.ErrorMessage(time.Duration.String))
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
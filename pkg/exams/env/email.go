@@ -0,0 +1,64 @@
+package env
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a bare email address, like `name@example.com`
+// Addresses with a display name, like `Name <name@example.com>`, aren't valid
+//
+// type: env.email,
+// vars: []string
+type Email struct {
+	Vars   []string
+	Level  int
+	Secret bool
+}
+
+func (r *Email) Type() string {
+	return "env.email"
+}
+
+func (r *Email) Fields() interface{} {
+	return &VarsFields{}
+}
+
+func (r *Email) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &VarsFields{}
+
+	return DefaultParse[*Email](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Email{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Secret}, nil
+	})
+}
+
+func (r *Email) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Email) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be an email address like name@example.com")
+		}
+
+		_, domain, _ := strings.Cut(value, "@")
+		if !isHost(domain) {
+			// The domain would be shown unmasked
+			if secret {
+				return invalidEnvVarStatus(name, r.Level, value, secret, "domain is not valid")
+			}
+
+			return invalidEnvVarStatus(name, r.Level, value, secret, "'"+domain+"' is not a valid domain")
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
@@ -2,9 +2,9 @@ package env
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/format"
 	"github.com/OJarrisonn/medik/pkg/medik"
@@ -13,29 +13,53 @@ import (
 // Function to get a parser for a given type `env.*`
 // The type is the part after `env.` in the exams.Exam type
 // Returns the parser and a boolean indicating if the parser was found
-func GetParser(ty string) (func(config config.Exam) (exams.Exam, error), bool) {
-	if parser, ok := parsers[ty]; ok {
-		return parser, ok
-	}
+func GetParser(ty string) (exams.Parser, bool) {
+	return parsers.Get(ty)
+}
 
-	return nil, false
+// Zero values of the exams of the category
+var all = []exams.Exam{
+	&IsSet{},
+	&NotEmpty{},
+	&Regex{},
+	&Option{},
+	&Int{},
+	&IntRange{},
+	&Float{},
+	&FloatRange{},
+	&File{},
+	&Dir{},
+	&Ipv4{},
+	&Ipv6{},
+	&Ip{},
+	&Hostname{},
+	&MatchesTemplate{},
+	&Secret{},
+	&Expr{},
+	&Json{},
+	&Yaml{},
+	&Duration{},
+	&Size{},
+	&Bool{},
+	&Port{},
+	&Cidr{},
+	&HostPort{},
+	&Mac{},
+	&Url{},
+	&Uuid{},
+	&Semver{},
+	&Email{},
+	&Base64{},
+	&Hex{},
+	&Jwt{},
+	&PathList{},
 }
 
-var parsers = map[string]func(config config.Exam) (exams.Exam, error){
-	exams.ExamType[*IsSet]():      exams.ExamParse[*IsSet](),
-	exams.ExamType[*NotEmpty]():   exams.ExamParse[*NotEmpty](),
-	exams.ExamType[*Regex]():      exams.ExamParse[*Regex](),
-	exams.ExamType[*Option]():     exams.ExamParse[*Option](),
-	exams.ExamType[*Int]():        exams.ExamParse[*Int](),
-	exams.ExamType[*IntRange]():   exams.ExamParse[*IntRange](),
-	exams.ExamType[*Float]():      exams.ExamParse[*Float](),
-	exams.ExamType[*FloatRange](): exams.ExamParse[*FloatRange](),
-	exams.ExamType[*File]():       exams.ExamParse[*File](),
-	exams.ExamType[*Dir]():        exams.ExamParse[*Dir](),
-	exams.ExamType[*Ipv4]():       exams.ExamParse[*Ipv4](),
-	exams.ExamType[*Ipv6]():       exams.ExamParse[*Ipv6](),
-	exams.ExamType[*Ip]():         exams.ExamParse[*Ip](),
-	exams.ExamType[*Hostname]():   exams.ExamParse[*Hostname](),
+var parsers = exams.NewParsers(all...)
+
+func init() {
+	exams.MustRegister("env", GetParser)
+	exams.RegisterFields(all...)
 }
 
 type EnvReport struct {
@@ -44,26 +68,43 @@ type EnvReport struct {
 	Statuses []EnvStatus
 }
 
+// The status of a variable. Origin is where its value comes from, like an env file, empty if unknown
 type EnvStatus struct {
 	Lvl     int
 	Var     string
 	Message string
+	Origin  string
 }
 
 func (r *EnvReport) Level() int {
 	return r.Lvl
 }
 
-func (r *EnvReport) Format(verbosity int) (int, string, string) {
+func (r *EnvReport) Format(verbosity int, noColor bool) (int, string, string) {
 	statuses := ""
 
 	for _, status := range r.Statuses {
 		if status.Lvl >= verbosity {
-			statuses += format.ReportStatus(status.Var, status.Message, status.Lvl) + "\n"
+			message := status.Message
+			if status.Origin != "" {
+				message += " (from " + status.Origin + ")"
+			}
+
+			statuses += format.ReportStatus(status.Var, message, status.Lvl, noColor) + "\n"
 		}
 	}
 
-	return r.Lvl, format.ReportHeader(r.Type, r.Lvl), statuses
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), statuses
+}
+
+func (r *EnvReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Statuses))
+
+	for i, status := range r.Statuses {
+		statuses[i] = exams.Status{Key: status.Var, Message: status.Message, Level: status.Lvl, Origin: status.Origin}
+	}
+
+	return exams.ReportData{Exam: r.Type, Level: r.Lvl, Statuses: statuses}
 }
 
 type VarsUnsetError struct {
@@ -92,7 +133,12 @@ func unsetEnvVarStatus(name string, level int) EnvStatus {
 }
 
 // Function to create a status for an environment variable whose value is invalid
-func invalidEnvVarStatus(name string, level int, value, message string) EnvStatus {
+// The value is masked if `secret` is set, so `message` must not include it either
+func invalidEnvVarStatus(name string, level int, value string, secret bool, message string) EnvStatus {
+	if secret {
+		value = MaskSecret(value)
+	}
+
 	return EnvStatus{
 		Lvl:     level,
 		Var:     name,
@@ -100,21 +146,35 @@ func invalidEnvVarStatus(name string, level int, value, message string) EnvStatu
 	}
 }
 
-// Default implementation for Examinate method of exams.Exam. It checks for the existence of the environment
-// variables in `vars`. For those who exist, it validates the value using the `validate` function which should
+// Masks the value of a secret so it can be shown in a report. Long values keep their first and
+// last 2 characters, so similar values can still be told apart
+func MaskSecret(value string) string {
+	if len(value) < 12 {
+		return "****"
+	}
+
+	return value[:2] + "****" + value[len(value)-2:]
+}
+
+// Default implementation for ExaminateEnv method of exams.EnvExam. It checks for the existence of the environment
+// variables in `vars` in `env`. For those who exist, it validates the value using the `validate` function which should
 // return a boolean (valid or not) and an error if not valid. Those who are not set are considered invalid and
 // append an UnsetEnvVarError to the errors slice. If no errors are found, it returns true and nil.
-func DefaultExaminate(exam string, logLevel int, vars []string, validate func(name, value string) EnvStatus) *EnvReport {
+// `validate` is told if the variable is a secret (see environment.IsSecret), in which case its messages must only
+// show the masked value and leave out errors that quote it, like the ones of strconv.
+// The same exam may be examinated from several goroutines at once, as long as `validate` doesn't mutate it
+func DefaultExaminate(exam string, logLevel int, vars []string, secret bool, env environment.Source, validate func(name, value string, secret bool) EnvStatus) *EnvReport {
 	statuses := []EnvStatus{}
 	level := 0
 
 	for _, name := range vars {
-		value, ok := os.LookupEnv(name)
+		value, ok := env.LookupEnv(name)
 		if !ok {
 			level = logLevel
 			statuses = append(statuses, unsetEnvVarStatus(name, logLevel))
 		} else {
-			status := validate(name, value)
+			status := validate(name, value, environment.IsSecret(env, name, secret))
+			status.Origin = environment.Origin(env, name)
 
 			if status.Lvl > logLevel {
 				status.Lvl = logLevel
@@ -131,14 +191,42 @@ func DefaultExaminate(exam string, logLevel int, vars []string, validate func(na
 	return &EnvReport{Type: exam, Lvl: level, Statuses: statuses}
 }
 
-func DefaultParse[E exams.Exam](config config.Exam, f func(config config.Exam) (exams.Exam, error)) (exams.Exam, error) {
+// The fields of the `env.*` exams that only need a list of variables
+// Exams with more fields embed it inline. Secret hides the values of the variables in the reports
+type VarsFields struct {
+	Vars   []string `yaml:"vars"`
+	Secret bool     `yaml:"secret"`
+}
+
+func (f *VarsFields) Variables() []string {
+	return f.Vars
+}
+
+// The fields of the `env.*` exams that check if the variables point to paths that exist or not
+type ExistsFields struct {
+	VarsFields `yaml:",inline"`
+	Exists     bool `yaml:"exists"`
+}
+
+// The fields of an `env.*` exam, which always have a list of variables
+type Fields interface {
+	Variables() []string
+}
+
+// Default implementation for Parse method of exams.Exam. It checks the type of the exam, decodes its
+// specific fields into `fields` and checks the list of variables isn't empty before calling `f`
+func DefaultParse[E exams.Exam](config config.Exam, fields Fields, f func(config config.Exam) (exams.Exam, error)) (exams.Exam, error) {
 	var e E
 	ty := e.Type()
 	if config.Type != ty {
 		return nil, &exams.WrongExamParserError{Source: config.Type, Using: ty}
 	}
 
-	if len(config.Vars) == 0 {
+	if err := config.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if len(fields.Variables()) == 0 {
 		return nil, &VarsUnsetError{Exam: ty}
 	}
 
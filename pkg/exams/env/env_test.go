@@ -1,10 +1,16 @@
 package env
 
 import (
+	"context"
+	"reflect"
 	"regexp"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 	"github.com/stretchr/testify/assert"
 )
@@ -47,11 +53,42 @@ func TestEnvParsersContainsAll(t *testing.T) {
 		(&Ipv6{}).Type(),
 		(&Ip{}).Type(),
 		(&Hostname{}).Type(),
+		(&MatchesTemplate{}).Type(),
+		(&Secret{}).Type(),
+		(&Expr{}).Type(),
+		(&Json{}).Type(),
+		(&Yaml{}).Type(),
+		(&Duration{}).Type(),
+		(&Size{}).Type(),
+		(&Bool{}).Type(),
+		(&Port{}).Type(),
+		(&Cidr{}).Type(),
+		(&HostPort{}).Type(),
+		(&Mac{}).Type(),
+		(&Url{}).Type(),
+		(&Uuid{}).Type(),
+		(&Semver{}).Type(),
+		(&Email{}).Type(),
+		(&Base64{}).Type(),
+		(&Hex{}).Type(),
+		(&Jwt{}).Type(),
+		(&PathList{}).Type(),
 	}
 
 	assert.ElementsMatch(t, known, registered)
 }
 
+// Strict decoding warns about fields an exam doesn't accept, so every exam with `vars` should accept `secret` too
+func TestEnvFieldsAcceptSecret(t *testing.T) {
+	for _, exam := range all {
+		keys := config.YAMLKeys(reflect.TypeOf(exam.(exams.FieldsExam).Fields()))
+
+		if slices.Contains(keys, "vars") {
+			assert.Contains(t, keys, "secret", exam.Type())
+		}
+	}
+}
+
 func TestEnvWrongParser(t *testing.T) {
 	parse, ok := GetParser("env.is-set")
 
@@ -68,7 +105,7 @@ func TestEnvIsSetNotEmpty(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -77,7 +114,7 @@ func TestEnvIsSetNotEmpty(t *testing.T) {
 	t.Setenv("VAR1", "")
 	t.Setenv("VAR2", " ")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -86,7 +123,7 @@ func TestEnvIsSetNotEmpty(t *testing.T) {
 	t.Setenv("VAR1", "value1")
 	t.Setenv("VAR2", "value2")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -98,7 +135,7 @@ func TestEnvRegex(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -107,7 +144,7 @@ func TestEnvRegex(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "value2")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -115,7 +152,7 @@ func TestEnvRegex(t *testing.T) {
 	// Test when environment variables match regex
 	t.Setenv("VAR1", "value1")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -127,7 +164,7 @@ func TestEnvOption(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -136,7 +173,7 @@ func TestEnvOption(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "option2")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -144,7 +181,7 @@ func TestEnvOption(t *testing.T) {
 	// Test when environment variables match options
 	t.Setenv("VAR1", "option1")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -155,7 +192,7 @@ func TestEnvInteger(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -164,7 +201,7 @@ func TestEnvInteger(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "123")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -172,7 +209,7 @@ func TestEnvInteger(t *testing.T) {
 	// Test when environment variables are integers
 	t.Setenv("VAR1", "456")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -183,7 +220,7 @@ func TestEnvIntegerRange(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -192,7 +229,7 @@ func TestEnvIntegerRange(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "50")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -200,7 +237,7 @@ func TestEnvIntegerRange(t *testing.T) {
 	// Test when environment variables are out of range
 	t.Setenv("VAR1", "5")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -208,7 +245,7 @@ func TestEnvIntegerRange(t *testing.T) {
 	// Test when environment variables are within range
 	t.Setenv("VAR1", "20")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -219,7 +256,7 @@ func TestEnvFloat(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -228,7 +265,7 @@ func TestEnvFloat(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "123.45")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -236,7 +273,7 @@ func TestEnvFloat(t *testing.T) {
 	// Test when environment variables are floats
 	t.Setenv("VAR1", "456.78")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -247,7 +284,7 @@ func TestEnvFloatRange(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -256,7 +293,7 @@ func TestEnvFloatRange(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "50.5")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -264,7 +301,7 @@ func TestEnvFloatRange(t *testing.T) {
 	// Test when environment variables are out of range
 	t.Setenv("VAR1", "5.5")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -272,7 +309,7 @@ func TestEnvFloatRange(t *testing.T) {
 	// Test when environment variables are within range
 	t.Setenv("VAR1", "20.5")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -283,7 +320,7 @@ func TestEnvFileExists(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -292,7 +329,7 @@ func TestEnvFileExists(t *testing.T) {
 	t.Setenv("VAR1", "/invalid/path")
 	t.Setenv("VAR2", "/etc/hosts")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -300,7 +337,7 @@ func TestEnvFileExists(t *testing.T) {
 	// Test when environment variables are valid files
 	t.Setenv("VAR1", "/etc/hosts")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -311,7 +348,7 @@ func TestEnvFileNotExists(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -320,7 +357,7 @@ func TestEnvFileNotExists(t *testing.T) {
 	t.Setenv("VAR1", "/etc/hosts")
 	t.Setenv("VAR2", "/invalid/path")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -328,7 +365,7 @@ func TestEnvFileNotExists(t *testing.T) {
 	// Test when environment variables are not valid files
 	t.Setenv("VAR1", "/invalid/path")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -338,7 +375,7 @@ func TestEnvIpv4Addr(t *testing.T) {
 	exam := &Ipv4{Vars: []string{"VAR1", "VAR2"}, Level: medik.ERROR}
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -347,7 +384,7 @@ func TestEnvIpv4Addr(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "192.168.1.1")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -355,7 +392,7 @@ func TestEnvIpv4Addr(t *testing.T) {
 	// Test when environment variables are valid IPv4 addresses
 	t.Setenv("VAR1", "10.0.0.1")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -366,7 +403,7 @@ func TestEnvIpv6Addr(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -375,7 +412,7 @@ func TestEnvIpv6Addr(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "2001:0db8:85a3:0000:0000:8a2e:0370:7334")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -383,7 +420,7 @@ func TestEnvIpv6Addr(t *testing.T) {
 	// Test when environment variables are valid IPv6 addresses
 	t.Setenv("VAR1", "fe80::1ff:fe23:4567:890a")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -394,7 +431,7 @@ func TestEnvIpAddr(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -403,7 +440,7 @@ func TestEnvIpAddr(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "192.168.1.1")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -411,7 +448,7 @@ func TestEnvIpAddr(t *testing.T) {
 	// Test when environment variables are valid IP addresses
 	t.Setenv("VAR1", "fe80::1ff:fe23:4567:890a")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -422,7 +459,7 @@ func TestEnvHostname(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -431,7 +468,7 @@ func TestEnvHostname(t *testing.T) {
 	t.Setenv("VAR1", "invalid")
 	t.Setenv("VAR2", "http://example.com")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -439,7 +476,7 @@ func TestEnvHostname(t *testing.T) {
 	// Test when environment variables are valid hostnames
 	t.Setenv("VAR1", "http://example.com")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
@@ -448,13 +485,13 @@ func TestEnvHostname(t *testing.T) {
 	t.Setenv("VAR1", "example.com")
 	t.Setenv("VAR2", "tcp://example.com")
 	report = exam2.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
 	t.Setenv("VAR1", "\n")
 	report = exam2.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -472,7 +509,7 @@ func TestEnvIsSetParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.is-set", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.is-set", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &IsSet{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -489,7 +526,7 @@ func TestEnvIsSetNotEmptyParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.not-empty", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.not-empty", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &NotEmpty{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -506,15 +543,15 @@ func TestEnvRegexParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test regex not set
-	_, err = exam.Parse(config.Exam{Type: "env.regex", Vars: []string{"VAR1"}})
+	_, err = exam.Parse(config.NewExam("env.regex", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.NotNil(t, err)
 
 	// Test invalid regex
-	_, err = exam.Parse(config.Exam{Type: "env.regex", Vars: []string{"VAR1"}, Regex: "["})
+	_, err = exam.Parse(config.NewExam("env.regex", map[string]interface{}{"vars": []string{"VAR1"}, "regex": "["}))
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.regex", Vars: []string{"VAR1"}, Regex: ".*"})
+	parsed, err := exam.Parse(config.NewExam("env.regex", map[string]interface{}{"vars": []string{"VAR1"}, "regex": ".*"}))
 	assert.Nil(t, err)
 	assert.NotNil(t, parsed)
 }
@@ -531,11 +568,11 @@ func TestEnvOptionParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test options not set
-	_, err = exam.Parse(config.Exam{Type: "env.options", Vars: []string{"VAR1"}})
+	_, err = exam.Parse(config.NewExam("env.options", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.options", Vars: []string{"VAR1"}, Options: []string{"option1"}})
+	parsed, err := exam.Parse(config.NewExam("env.options", map[string]interface{}{"vars": []string{"VAR1"}, "options": []string{"option1"}}))
 	assert.Nil(t, err)
 	assert.NotNil(t, parsed)
 }
@@ -552,7 +589,7 @@ func TestEnvIntegerParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.int", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.int", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Int{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -569,15 +606,15 @@ func TestEnvIntegerRangeParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test min not an integer
-	_, err = exam.Parse(config.Exam{Type: "env.int-range", Vars: []string{"VAR1"}, Min: "min", Max: 10})
+	_, err = exam.Parse(config.NewExam("env.int-range", map[string]interface{}{"vars": []string{"VAR1"}, "min": "min", "max": 10}))
 	assert.NotNil(t, err)
 
 	// Test max not an integer
-	_, err = exam.Parse(config.Exam{Type: "env.int-range", Vars: []string{"VAR1"}, Min: 0, Max: "max"})
+	_, err = exam.Parse(config.NewExam("env.int-range", map[string]interface{}{"vars": []string{"VAR1"}, "min": 0, "max": "max"}))
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.int-range", Vars: []string{"VAR1"}, Min: 0, Max: 10})
+	parsed, err := exam.Parse(config.NewExam("env.int-range", map[string]interface{}{"vars": []string{"VAR1"}, "min": 0, "max": 10}))
 	assert.Nil(t, err)
 	assert.Equal(t, &IntRange{Vars: []string{"VAR1"}, Min: 0, Max: 10, Level: medik.ERROR}, parsed)
 }
@@ -594,7 +631,7 @@ func TestEnvFloatParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.float", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.float", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Float{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -611,15 +648,15 @@ func TestEnvFloatRangeParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test min not a float
-	_, err = exam.Parse(config.Exam{Type: "env.float-range", Vars: []string{"VAR1"}, Min: "min", Max: 10.0})
+	_, err = exam.Parse(config.NewExam("env.float-range", map[string]interface{}{"vars": []string{"VAR1"}, "min": "min", "max": 10.0}))
 	assert.NotNil(t, err)
 
 	// Test max not a float
-	_, err = exam.Parse(config.Exam{Type: "env.float-range", Vars: []string{"VAR1"}, Min: 0.0, Max: "max"})
+	_, err = exam.Parse(config.NewExam("env.float-range", map[string]interface{}{"vars": []string{"VAR1"}, "min": 0.0, "max": "max"}))
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.float-range", Vars: []string{"VAR1"}, Min: 0.0, Max: 10.0})
+	parsed, err := exam.Parse(config.NewExam("env.float-range", map[string]interface{}{"vars": []string{"VAR1"}, "min": 0.0, "max": 10.0}))
 	assert.Nil(t, err)
 	assert.Equal(t, &FloatRange{Vars: []string{"VAR1"}, Min: 0.0, Max: 10.0, Level: medik.ERROR}, parsed)
 }
@@ -636,7 +673,7 @@ func TestEnvFileParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.file", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.file", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &File{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -653,7 +690,7 @@ func TestEnvDirParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.dir", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.dir", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Dir{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -670,7 +707,7 @@ func TestEnvIpv4AddrParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.ipv4", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.ipv4", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Ipv4{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -687,7 +724,7 @@ func TestEnvIpv6AddrParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.ipv6", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.ipv6", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Ipv6{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -704,7 +741,7 @@ func TestEnvIpAddrParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.ip", Vars: []string{"VAR1"}})
+	parsed, err := exam.Parse(config.NewExam("env.ip", map[string]interface{}{"vars": []string{"VAR1"}}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Ip{Vars: []string{"VAR1"}, Level: medik.ERROR}, parsed)
 }
@@ -721,7 +758,84 @@ func TestEnvHostnameParse(t *testing.T) {
 	assert.NotNil(t, err)
 
 	// Test valid config
-	parsed, err := exam.Parse(config.Exam{Type: "env.hostname", Vars: []string{"VAR1"}, Protocol: "http"})
+	parsed, err := exam.Parse(config.NewExam("env.hostname", map[string]interface{}{"vars": []string{"VAR1"}, "protocol": "http"}))
 	assert.Nil(t, err)
 	assert.Equal(t, &Hostname{Vars: []string{"VAR1"}, Protocol: "http", Level: medik.ERROR}, parsed)
 }
+
+func TestEnvMaskSecret(t *testing.T) {
+	env := environment.Map{"SHORT": "hunter2", "LONG": "sk_live_1234567890"}
+	exam := &Regex{Vars: []string{"SHORT", "LONG"}, Regex: regexp.MustCompile(`^$`), Level: medik.ERROR, Secret: true}
+
+	// Values of secret variables are masked
+	_, _, body := exam.ExaminateEnv(context.Background(), env).Format(medik.OK, true)
+	assert.Contains(t, body, "'****' is not valid")
+	assert.Contains(t, body, "'sk****90' is not valid")
+	assert.NotContains(t, body, "hunter2")
+	assert.NotContains(t, body, "sk_live_1234567890")
+
+	// Variables matching the patterns of the environment are secrets too
+	exam.Secret = false
+	_, _, body = exam.ExaminateEnv(context.Background(), environment.WithSecrets(env, []string{"short"}, false)).Format(medik.OK, true)
+	assert.NotContains(t, body, "hunter2")
+	assert.Contains(t, body, "sk_live_1234567890")
+
+	// Unless secrets are shown
+	exam.Secret = true
+	_, _, body = exam.ExaminateEnv(context.Background(), environment.WithSecrets(env, nil, true)).Format(medik.OK, true)
+	assert.Contains(t, body, "hunter2")
+
+	assert.Equal(t, "****", MaskSecret("12345678901"))
+	assert.Equal(t, "12****12", MaskSecret("123456789012"))
+}
+
+func TestEnvMaskShortSecret(t *testing.T) {
+	env := environment.Map{"A": "1", "B": "10", "C": "100"}
+	exam := &IntRange{Vars: []string{"A", "B", "C"}, Min: 200, Max: 1000, Level: medik.ERROR, Secret: true}
+
+	// Short values are also part of the rest of the message, which is left as it is
+	report := exam.ExaminateEnv(context.Background(), env).(*EnvReport)
+	for _, status := range report.Statuses {
+		assert.Equal(t, "'****' is not valid: value should be in the range [200,1000]", status.Message, status.Var)
+	}
+}
+
+func TestEnvMaskEscapedSecret(t *testing.T) {
+	env := environment.Map{"QUOTE": `sk-live"abcdef1234567`, "TAB": "sk-live\tabcdef1234567"}
+
+	// strconv quotes the values in its errors, so they wouldn't match the value itself
+	for _, exam := range []exams.EnvExam{
+		&Int{Vars: []string{"QUOTE", "TAB"}, Level: medik.ERROR, Secret: true},
+		&Float{Vars: []string{"QUOTE", "TAB"}, Level: medik.ERROR, Secret: true},
+		&IntRange{Vars: []string{"QUOTE", "TAB"}, Max: 10, Level: medik.ERROR, Secret: true},
+		&FloatRange{Vars: []string{"QUOTE", "TAB"}, Max: 10, Level: medik.ERROR, Secret: true},
+	} {
+		_, _, body := exam.ExaminateEnv(context.Background(), env).Format(medik.OK, true)
+		assert.Contains(t, body, "'sk****67' is not valid")
+		assert.NotContains(t, body, "abcdef")
+	}
+}
+
+// Run with -race to catch exams sharing state between runs
+func TestEnvConcurrentExaminate(t *testing.T) {
+	exam := &Regex{Vars: []string{"A", "B", "C"}, Regex: regexp.MustCompile(`^[a-z]+$`), Level: medik.ERROR}
+	env := environment.WithSecrets(environment.Map{"A": "abc", "B": "123"}, []string{"B"}, false)
+	expected := exam.ExaminateEnv(context.Background(), env)
+
+	reports := make([]interface{}, 16)
+	wg := sync.WaitGroup{}
+
+	for i := range reports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i] = exam.ExaminateEnv(context.Background(), env)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, report := range reports {
+		assert.Equal(t, expected, report)
+	}
+}
@@ -0,0 +1,139 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/expr"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a boolean expression over environment variables holds, like `int(MIN_POOL) <= int(MAX_POOL)`
+// See the expr package for the syntax and the functions available
+// The status is named after `name`, or the expression itself if not set. `message` replaces "is false"
+//
+// type: env.expr,
+// expr: string,
+// name: string,
+// message: string
+type Expr struct {
+	Expr    *expr.Expr
+	Name    string
+	Message string
+	Level   int
+	Secret  bool
+}
+
+// The fields of an env.expr exam
+type ExprFields struct {
+	Expr    string `yaml:"expr"`
+	Name    string `yaml:"name"`
+	Message string `yaml:"message"`
+	Secret  bool   `yaml:"secret"`
+}
+
+func (r *Expr) Type() string {
+	return "env.expr"
+}
+
+func (r *Expr) Fields() interface{} {
+	return &ExprFields{}
+}
+
+func (r *Expr) Parse(conf config.Exam) (exams.Exam, error) {
+	if conf.Type != r.Type() {
+		return nil, &exams.WrongExamParserError{Source: conf.Type, Using: r.Type()}
+	}
+
+	fields := &ExprFields{}
+	if err := conf.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if fields.Expr == "" {
+		return nil, &exams.MissingFieldError{Field: "expr", Exam: r.Type()}
+	}
+
+	e, err := expr.Parse(fields.Expr)
+	if err != nil {
+		return nil, &exams.FieldValueError{Field: "expr", Exam: r.Type(), Value: fields.Expr, Message: err.Error()}
+	}
+
+	name := fields.Name
+	if name == "" {
+		name = fields.Expr
+	}
+
+	return &Expr{e, name, fields.Message, medik.LogLevelFromStr(conf.Level), fields.Secret}, nil
+}
+
+func (r *Expr) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Expr) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	ok, failed, err := r.Expr.Check(env.LookupEnv)
+
+	status := validEnvVarStatus(r.Name)
+
+	switch {
+	case err != nil:
+		status = EnvStatus{Lvl: r.Level, Var: r.Name, Message: "can't be evaluated: " + r.errorMessage(err, env) + r.values(r.Expr.Root(), env)}
+	case !ok:
+		message := r.Message
+		if message == "" {
+			message = "is false"
+			if failed != r.Expr.Root() {
+				message += " because " + failed.Source() + " is false"
+			}
+		}
+
+		status = EnvStatus{Lvl: r.Level, Var: r.Name, Message: message + r.values(failed, env)}
+	}
+
+	return &EnvReport{Type: r.Type(), Lvl: status.Lvl, Statuses: []EnvStatus{status}}
+}
+
+// Returns the message of an evaluation error. Errors show the values of the operands, which may be derived from
+// secret variables, like `lower(API_KEY)`, so they're left out if any variable of the expression is a secret
+func (r *Expr) errorMessage(err error, env environment.Source) string {
+	if !slices.ContainsFunc(expr.Vars(r.Expr.Root()), func(name string) bool { return environment.IsSecret(env, name, r.Secret) }) {
+		return err.Error()
+	}
+
+	var evalErr *expr.EvalError
+	if errors.As(err, &evalErr) {
+		return evalErr.RedactedError()
+	}
+
+	return "the evaluation failed"
+}
+
+// Lists the values of the variables used by `node`, like ` (MIN_POOL='20', MAX_POOL is not set)`
+func (r *Expr) values(node expr.Node, env environment.Source) string {
+	values := []string{}
+
+	for _, name := range expr.Vars(node) {
+		value, ok := env.LookupEnv(name)
+
+		switch {
+		case !ok:
+			values = append(values, name+" is not set")
+		case environment.IsSecret(env, name, r.Secret):
+			values = append(values, name+"='"+MaskSecret(value)+"'")
+		default:
+			values = append(values, name+"='"+value+"'")
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(values, ", ") + ")"
+}
@@ -0,0 +1,73 @@
+package env
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseExpr(t *testing.T, fields map[string]interface{}) *Expr {
+	exam, err := (&Expr{}).Parse(config.NewExam("env.expr", fields))
+	assert.Nil(t, err)
+
+	return exam.(*Expr)
+}
+
+func TestEnvExpr(t *testing.T) {
+	exam := parseExpr(t, map[string]interface{}{"expr": "!bool(DB_SSL) || set(DB_CERT)", "name": "DB_CERT"})
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"DB_SSL": "false"}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Level())
+	assert.Equal(t, []EnvStatus{{Lvl: medik.OK, Var: "DB_CERT", Message: "is valid"}}, report.Statuses)
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"DB_SSL": "true"}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, "is false (DB_SSL='true', DB_CERT is not set)", report.Statuses[0].Message)
+
+	// The sub-expression that failed is reported
+	exam = parseExpr(t, map[string]interface{}{"expr": "set(MIN_POOL) && int(MIN_POOL) <= int(MAX_POOL)"})
+	exam.Level = medik.WARNING
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"MIN_POOL": "20", "MAX_POOL": "10"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{{
+		Lvl:     medik.WARNING,
+		Var:     "set(MIN_POOL) && int(MIN_POOL) <= int(MAX_POOL)",
+		Message: "is false because int(MIN_POOL) <= int(MAX_POOL) is false (MIN_POOL='20', MAX_POOL='10')",
+	}}, report.Statuses)
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"MIN_POOL": "20", "MAX_POOL": "many"}).(*EnvReport)
+	assert.Equal(t, "can't be evaluated: int(MAX_POOL): 'many' is not an integer (MIN_POOL='20', MAX_POOL='many')", report.Statuses[0].Message)
+}
+
+func TestEnvExprSecret(t *testing.T) {
+	exam := parseExpr(t, map[string]interface{}{"expr": "url(DATABASE_URL).host == DB_HOST", "message": "should point to DB_HOST"})
+	env := environment.WithSecrets(environment.Map{"DATABASE_URL": "postgres://admin:hunter2@db:5432", "DB_HOST": "localhost"}, []string{"*_URL"}, false)
+
+	report := exam.ExaminateEnv(context.Background(), env).(*EnvReport)
+	assert.Equal(t, "should point to DB_HOST (DATABASE_URL='po****32', DB_HOST='localhost')", report.Statuses[0].Message)
+
+	report = exam.ExaminateEnv(context.Background(), environment.WithSecrets(environment.Map{"DATABASE_URL": "hunter2", "DB_HOST": "db"}, []string{"*_URL"}, false)).(*EnvReport)
+	assert.Equal(t, "can't be evaluated: url(DATABASE_URL): the value is not an absolute URL (DATABASE_URL='****', DB_HOST='db')", report.Statuses[0].Message)
+
+	// Values derived from a secret aren't shown either
+	exam = parseExpr(t, map[string]interface{}{"expr": "int(lower(API_KEY)) > 0", "secret": true})
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "SK-LIVE-ABCDEFGHIJ"}).(*EnvReport)
+	assert.Equal(t, "can't be evaluated: int(lower(API_KEY)): the value is not an integer (API_KEY='SK****IJ')", report.Statuses[0].Message)
+
+	// Short values don't change the rest of the message
+	exam = parseExpr(t, map[string]interface{}{"expr": "API_KEY == 'b'", "secret": true})
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "a"}).(*EnvReport)
+	assert.Equal(t, "is false (API_KEY='****')", report.Statuses[0].Message)
+}
+
+func TestEnvExprParse(t *testing.T) {
+	_, err := (&Expr{}).Parse(config.NewExam("env.expr", map[string]interface{}{}))
+	assert.NotNil(t, err)
+
+	_, err = (&Expr{}).Parse(config.NewExam("env.expr", map[string]interface{}{"expr": "int(A"}))
+	assert.Equal(t, "invalid value 'int(A' for field `expr` in exam env.expr: column 6: expected ',', found the end of the expression", err.Error())
+}
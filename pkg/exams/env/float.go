@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -13,27 +15,47 @@ import (
 // type: env.float,
 // vars: []string
 type Float struct {
-	Vars  []string
-	Level int
+	Vars   []string
+	Level  int
+	Secret bool
 }
 
 func (r *Float) Type() string {
 	return "env.float"
 }
 
+func (r *Float) Fields() interface{} {
+	return &VarsFields{}
+}
+
 func (r *Float) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Float](conf, func(conf config.Exam) (exams.Exam, error) {
-		return &Float{conf.Vars, medik.LogLevelFromStr(conf.Level)}, nil
+	fields := &VarsFields{}
+
+	return DefaultParse[*Float](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Float{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Secret}, nil
 	})
 }
 
 func (r *Float) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Float) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		_, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			return invalidEnvVarStatus(name, r.Level, value, err.Error())
+			return invalidEnvVarStatus(name, r.Level, value, secret, floatErrorMessage(err, secret))
 		}
 
 		return validEnvVarStatus(name)
 	})
 }
+
+// The error of strconv quotes the value, so it's left out for secrets
+func floatErrorMessage(err error, secret bool) string {
+	if secret {
+		return "value should be a number"
+	}
+
+	return err.Error()
+}
@@ -1,10 +1,11 @@
 package env
 
 import (
-	"fmt"
+	"context"
 	"strconv"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -17,51 +18,53 @@ import (
 // min: float,
 // max: float
 type FloatRange struct {
-	Vars  []string
-	Level int
-	Min   float64
-	Max   float64
+	Vars   []string
+	Level  int
+	Min    float64
+	Max    float64
+	Secret bool
+}
+
+// The fields of an env.float-range exam
+type FloatRangeFields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
 }
 
 func (r *FloatRange) Type() string {
 	return "env.float-range"
 }
 
-func (r *FloatRange) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*FloatRange](conf, func(conf config.Exam) (exams.Exam, error) {
-		if conf.Min == nil {
-			return nil, &exams.MissingFieldError{Field: "min", Exam: r.Type()}
-		}
-
-		switch conf.Min.(type) {
-		case float64:
-		default:
-			return nil, &exams.FieldValueError{Field: "min", Exam: r.Type(), Value: fmt.Sprint(conf.Min), Message: "expected a float value"}
-		}
+func (r *FloatRange) Fields() interface{} {
+	return &FloatRangeFields{}
+}
 
-		if conf.Max == nil {
-			return nil, &exams.MissingFieldError{Field: "max", Exam: r.Type()}
-		}
+func (r *FloatRange) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &FloatRangeFields{}
 
-		switch conf.Max.(type) {
-		case float64:
-		default:
-			return nil, &exams.FieldValueError{Field: "max", Exam: r.Type(), Value: fmt.Sprint(conf.Max), Message: "expected a float value"}
+	return DefaultParse[*FloatRange](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		bounds, err := parseRange(fields.RangeFields, r.Type(), true, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+		if err != nil {
+			return nil, err
 		}
 
-		return &FloatRange{conf.Vars, medik.LogLevelFromStr(conf.Level), conf.Min.(float64), conf.Max.(float64)}, nil
+		return &FloatRange{fields.Vars, medik.LogLevelFromStr(conf.Level), bounds.Min, bounds.Max, fields.Secret}, nil
 	})
 }
 
 func (r *FloatRange) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name string, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *FloatRange) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		num, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			return invalidEnvVarStatus(name, r.Level, value, err.Error())
+			return invalidEnvVarStatus(name, r.Level, value, secret, floatErrorMessage(err, secret))
 		}
 
-		if num < r.Min || num > r.Max {
-			return invalidEnvVarStatus(name, r.Level, value, fmt.Sprintf("value should be in the range [%v,%v]", r.Min, r.Max))
+		if bounds := (Range[float64]{r.Min, r.Max, true, true}); !bounds.Contains(num) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, bounds.ErrorMessage(formatBound))
 		}
 
 		return validEnvVarStatus(name)
@@ -0,0 +1,138 @@
+package env
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvUuid(t *testing.T) {
+	exam := &Uuid{Vars: []string{"A", "B", "C", "D", "E"}, Level: medik.ERROR, Versions: []int{4, 7}}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{
+		"A": "123e4567-e89b-42d3-a456-426614174000",
+		"B": "123e4567-e89b-12d3-a456-426614174000",
+		"C": "123e4567e89b42d3a456426614174000",
+		"D": "123e4567-e89b-42d3-c456-426614174000",
+		"E": "123e4567-e89b-42d3-a456-42661417400g",
+	}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "'123e4567-e89b-12d3-a456-426614174000' is not valid: value should be a UUID of version [4 7], got version 1"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'123e4567e89b42d3a456426614174000' is not valid: value should be a UUID like 123e4567-e89b-42d3-a456-426614174000"},
+		{Lvl: medik.ERROR, Var: "D", Message: "'123e4567-e89b-42d3-c456-426614174000' is not valid: value should be a UUID of the RFC 9562 variant"},
+		{Lvl: medik.ERROR, Var: "E", Message: "'123e4567-e89b-42d3-a456-42661417400g' is not valid: value should only have hexadecimal digits and dashes"},
+	}, report.Statuses)
+
+	_, err := (&Uuid{}).Parse(config.NewExam("env.uuid", map[string]interface{}{"vars": []string{"A"}, "versions": []int{9}}))
+	assert.NotNil(t, err)
+}
+
+func TestEnvSemver(t *testing.T) {
+	exam, err := (&Semver{}).Parse(config.NewExam("env.semver", map[string]interface{}{"vars": []string{"A", "B", "C"}, "constraint": ">=1.21 <2"}))
+	assert.Nil(t, err)
+
+	report := exam.(*Semver).ExaminateEnv(context.Background(), environment.Map{"A": "1.22.1", "B": "v1.22", "C": "2.0.0"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "invalid version 'v1.22': expected MAJOR.MINOR.PATCH"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'2.0.0' is not valid: value should match >=1.21 <2"},
+	}, report.Statuses)
+
+	exam.(*Semver).Loose = true
+	report = exam.(*Semver).ExaminateEnv(context.Background(), environment.Map{"A": "1.22.1", "B": "v1.22", "C": "1.21.0-rc.1"}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Statuses[1].Lvl)
+
+	_, err = (&Semver{}).Parse(config.NewExam("env.semver", map[string]interface{}{"vars": []string{"A"}, "constraint": ">=x"}))
+	assert.NotNil(t, err)
+}
+
+func TestEnvEmail(t *testing.T) {
+	exam := &Email{Vars: []string{"A", "B", "C"}, Level: medik.ERROR}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"A": "ops@example.com", "B": "Ops <ops@example.com>", "C": "ops@example..com"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "'Ops <ops@example.com>' is not valid: value should be an email address like name@example.com"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'ops@example..com' is not valid: value should be an email address like name@example.com"},
+	}, report.Statuses)
+
+	// The domain of a secret isn't shown either
+	exam = &Email{Vars: []string{"A"}, Level: medik.ERROR, Secret: true}
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"A": "me@bad_domain!"}).(*EnvReport)
+	assert.Equal(t, "'me****n!' is not valid: domain is not valid", report.Statuses[0].Message)
+}
+
+func TestEnvBase64(t *testing.T) {
+	exam, err := (&Base64{}).Parse(config.NewExam("env.base64", map[string]interface{}{"vars": []string{"A", "B", "C", "D"}, "min": 16}))
+	assert.Nil(t, err)
+
+	report := exam.(*Base64).ExaminateEnv(context.Background(), environment.Map{
+		"A": base64.StdEncoding.EncodeToString(make([]byte, 32)),
+		"B": base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff, 0xfe, 0xfd, 0xfb, 0xff, 0xfe, 0xfd, 0xfb, 0xff, 0xfe, 0xfd, 0xfb, 0xff, 0xfe, 0xfd}),
+		"C": "c2hvcnQ=",
+		"D": "not base64!",
+	}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.OK, Var: "B", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'c2hvcnQ=' is not valid: decoded value should be at least 16B, got 5B"},
+		{Lvl: medik.ERROR, Var: "D", Message: "'not base64!' is not valid: value should be base64: illegal base64 data at input byte 3"},
+	}, report.Statuses)
+
+	exam.(*Base64).Encoding = "std"
+	report = exam.(*Base64).ExaminateEnv(context.Background(), environment.Map{"A": "c2hvcnQ"}).(*EnvReport)
+	assert.Equal(t, "'c2hvcnQ' is not valid: value should be std base64: illegal base64 data at input byte 4", report.Statuses[0].Message)
+}
+
+func TestEnvHex(t *testing.T) {
+	exam, err := (&Hex{}).Parse(config.NewExam("env.hex", map[string]interface{}{"vars": []string{"A", "B", "C"}, "min": 4, "max": 4}))
+	assert.Nil(t, err)
+
+	report := exam.(*Hex).ExaminateEnv(context.Background(), environment.Map{"A": "deadBEEF", "B": "abc", "C": "dead"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "'abc' is not valid: value should be hexadecimal: encoding/hex: odd length hex string"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'dead' is not valid: decoded value should be in the range [4B,4B], got 2B"},
+	}, report.Statuses)
+}
+
+// Builds an unsigned JWT with the given header and payload
+func jwt(header, payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2ln"
+}
+
+func TestEnvJwt(t *testing.T) {
+	exam := &Jwt{Vars: []string{"A", "B", "C", "D", "E", "F", "G"}, Level: medik.ERROR, RequireExp: true}
+	future, past := time.Now().Add(time.Hour).Unix(), time.Unix(1700000000, 0)
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{
+		"A": jwt(`{"alg":"HS256"}`, fmt.Sprintf(`{"exp":%v}`, future)),
+		"B": jwt(`{"alg":"HS256"}`, fmt.Sprintf(`{"exp":%v}`, past.Unix())),
+		"C": jwt(`{"alg":"HS256"}`, `{"sub":"medik"}`),
+		"D": jwt(`{"alg":"none"}`, fmt.Sprintf(`{"exp":%v}`, future)),
+		"E": jwt(`{"typ":"JWT"}`, `{}`),
+		"F": "a.b",
+		"G": jwt(`{"alg":"HS256"}`, fmt.Sprintf(`{"exp":%v,"nbf":%v}`, future, future)),
+	}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "expired at 2023-11-14T22:13:20Z"},
+		{Lvl: medik.ERROR, Var: "C", Message: "has no expiration (exp claim)"},
+		{Lvl: medik.WARNING, Var: "D", Message: "is not signed (alg is none)"},
+		{Lvl: medik.ERROR, Var: "E", Message: "is not a valid JWT: the header has no alg"},
+		{Lvl: medik.ERROR, Var: "F", Message: "is not a valid JWT: expected 3 parts separated by dots, got 2"},
+		{Lvl: medik.ERROR, Var: "G", Message: "is not valid before " + time.Unix(future, 0).UTC().Format(time.RFC3339)},
+	}, report.Statuses)
+
+	// Like the other env exams, so strict decoding doesn't warn about `secret`
+	assert.Equal(t, []string{"vars", "secret", "require-exp"}, config.YAMLKeys(reflect.TypeOf(exam.Fields())))
+}
@@ -0,0 +1,73 @@
+package env
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to hexadecimal data, like `deadbeef`, optionally with a decoded size within a range
+// Min and Max are inclusive sizes like `32` or `1KiB`, and both are optional
+//
+// type: env.hex,
+// vars: []string,
+// min: size,
+// max: size
+type Hex struct {
+	Vars   []string
+	Level  int
+	Range  Range[int64]
+	Secret bool
+}
+
+// The fields of an env.hex exam
+type HexFields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
+}
+
+func (r *Hex) Type() string {
+	return "env.hex"
+}
+
+func (r *Hex) Fields() interface{} {
+	return &HexFields{}
+}
+
+func (r *Hex) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &HexFields{}
+
+	return DefaultParse[*Hex](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		bounds, err := parseRange(fields.RangeFields, r.Type(), false, ParseSize)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Hex{fields.Vars, medik.LogLevelFromStr(conf.Level), bounds, fields.Secret}, nil
+	})
+}
+
+func (r *Hex) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Hex) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			// The error quotes the invalid character
+			if secret {
+				return invalidEnvVarStatus(name, r.Level, value, secret, "value should be hexadecimal")
+			}
+
+			return invalidEnvVarStatus(name, r.Level, value, secret, fmt.Sprintf("value should be hexadecimal: %v", err))
+		}
+
+		return decodedSizeStatus(name, r.Level, value, secret, r.Range, len(decoded))
+	})
+}
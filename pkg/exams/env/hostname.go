@@ -1,10 +1,12 @@
 package env
 
 import (
+	"context"
 	"fmt"
 	neturl "net/url"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -18,24 +20,41 @@ type Hostname struct {
 	Vars     []string
 	Level    int
 	Protocol string
+	Secret   bool
+}
+
+// The fields of an env.hostname exam
+type HostnameFields struct {
+	VarsFields `yaml:",inline"`
+	Protocol   string `yaml:"protocol"`
 }
 
 func (r *Hostname) Type() string {
 	return "env.hostname"
 }
 
+func (r *Hostname) Fields() interface{} {
+	return &HostnameFields{}
+}
+
 func (r *Hostname) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Hostname](conf, func(conf config.Exam) (exams.Exam, error) {
-		return &Hostname{conf.Vars, medik.LogLevelFromStr(conf.Level), conf.Protocol}, nil
+	fields := &HostnameFields{}
+
+	return DefaultParse[*Hostname](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Hostname{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Protocol, fields.Secret}, nil
 	})
 }
 
 func (r *Hostname) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Hostname) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		ok, _ := r.validateUrl(value)
 
 		if !ok {
-			return invalidEnvVarStatus(name, r.Level, value, "value should be a valid URL")
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a valid URL")
 		}
 
 		return validEnvVarStatus(name)
@@ -0,0 +1,94 @@
+package env
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a `host:port` address, like `db:5432` or `[::1]:8080`
+// The host must be an IP address or a valid hostname, and the port must be from 1 to 65535
+//
+// type: env.hostport,
+// vars: []string
+type HostPort struct {
+	Vars   []string
+	Level  int
+	Secret bool
+}
+
+func (r *HostPort) Type() string {
+	return "env.hostport"
+}
+
+func (r *HostPort) Fields() interface{} {
+	return &VarsFields{}
+}
+
+func (r *HostPort) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &VarsFields{}
+
+	return DefaultParse[*HostPort](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &HostPort{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Secret}, nil
+	})
+}
+
+func (r *HostPort) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *HostPort) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		host, port, err := net.SplitHostPort(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be an address like host:port")
+		}
+
+		if !isHost(host) {
+			// The host would be shown unmasked
+			if secret {
+				return invalidEnvVarStatus(name, r.Level, value, secret, "host is not an IP address or a valid hostname")
+			}
+
+			return invalidEnvVarStatus(name, r.Level, value, secret, "'"+host+"' is not an IP address or a valid hostname")
+		}
+
+		if _, err := parsePort(port); err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, err.Error())
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+// Returns if `host` is an IP address or a valid hostname (RFC 1123)
+func isHost(host string) bool {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -14,31 +16,51 @@ import (
 // type: env.int,
 // vars: []string
 type Int struct {
-	Vars  []string
-	Level int
+	Vars   []string
+	Level  int
+	Secret bool
 }
 
 func (r *Int) Type() string {
 	return "env.int"
 }
 
+func (r *Int) Fields() interface{} {
+	return &VarsFields{}
+}
+
 func (r *Int) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Int](conf, func(conf config.Exam) (exams.Exam, error) {
-		return &Int{conf.Vars, medik.LogLevelFromStr(conf.Level)}, nil
+	fields := &VarsFields{}
+
+	return DefaultParse[*Int](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Int{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Secret}, nil
 	})
 }
 
 func (r *Int) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Int) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		_, err := strconv.Atoi(value)
 		if err != nil {
-			return invalidEnvVarStatus(name, r.Level, value, r.ErrorMessage(err))
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.ErrorMessage(err, secret))
 		}
 
 		return validEnvVarStatus(name)
 	})
 }
 
-func (r *Int) ErrorMessage(err error) string {
+func (r *Int) ErrorMessage(err error, secret bool) string {
+	return numberErrorMessage(err, secret)
+}
+
+// The error of strconv quotes the value, so it's left out for secrets
+func numberErrorMessage(err error, secret bool) string {
+	if secret {
+		return "value should be a number"
+	}
+
 	return "value should be a number. " + err.Error()
 }
@@ -1,10 +1,11 @@
 package env
 
 import (
-	"fmt"
+	"context"
 	"strconv"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -17,51 +18,53 @@ import (
 // min: int,
 // max: int
 type IntRange struct {
-	Vars  []string
-	Level int
-	Min   int
-	Max   int
+	Vars   []string
+	Level  int
+	Min    int
+	Max    int
+	Secret bool
+}
+
+// The fields of an env.int-range exam
+type IntRangeFields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
 }
 
 func (r *IntRange) Type() string {
 	return "env.int-range"
 }
 
-func (r *IntRange) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*IntRange](conf, func(config config.Exam) (exams.Exam, error) {
-		if config.Min == nil {
-			return nil, &exams.MissingFieldError{Field: "min", Exam: r.Type()}
-		}
-
-		switch config.Min.(type) {
-		case int:
-		default:
-			return nil, &exams.FieldValueError{Field: "min", Exam: r.Type(), Value: fmt.Sprint(config.Min), Message: "expected an integer value"}
-		}
+func (r *IntRange) Fields() interface{} {
+	return &IntRangeFields{}
+}
 
-		if config.Max == nil {
-			return nil, &exams.MissingFieldError{Field: "max", Exam: r.Type()}
-		}
+func (r *IntRange) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &IntRangeFields{}
 
-		switch config.Max.(type) {
-		case int:
-		default:
-			return nil, &exams.FieldValueError{Field: "max", Exam: r.Type(), Value: fmt.Sprint(config.Max), Message: "expected an integer value"}
+	return DefaultParse[*IntRange](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		bounds, err := parseRange(fields.RangeFields, r.Type(), true, strconv.Atoi)
+		if err != nil {
+			return nil, err
 		}
 
-		return &IntRange{config.Vars, medik.LogLevelFromStr(config.Level), config.Min.(int), config.Max.(int)}, nil
+		return &IntRange{fields.Vars, medik.LogLevelFromStr(conf.Level), bounds.Min, bounds.Max, fields.Secret}, nil
 	})
 }
 
 func (r *IntRange) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *IntRange) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		num, err := strconv.Atoi(value)
 		if err != nil {
-			return invalidEnvVarStatus(name, r.Level, value, "value should be a number. "+err.Error())
+			return invalidEnvVarStatus(name, r.Level, value, secret, numberErrorMessage(err, secret))
 		}
 
-		if num < r.Min || num > r.Max {
-			return invalidEnvVarStatus(name, r.Level, value, fmt.Sprintf("value should be in the range [%v,%v]", r.Min, r.Max))
+		if bounds := (Range[int]{r.Min, r.Max, true, true}); !bounds.Contains(num) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, bounds.ErrorMessage(formatBound))
 		}
 
 		return validEnvVarStatus(name)
@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -13,26 +15,37 @@ import (
 // type: env.ipv4,
 // vars: []string
 type Ipv4 struct {
-	Vars  []string
-	Level int
+	Vars   []string
+	Level  int
+	Secret bool
 }
 
 func (r *Ipv4) Type() string {
 	return "env.ipv4"
 }
 
+func (r *Ipv4) Fields() interface{} {
+	return &VarsFields{}
+}
+
 func (r *Ipv4) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Ipv4](conf, func(config config.Exam) (exams.Exam, error) {
-		return &Ipv4{config.Vars, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &VarsFields{}
+
+	return DefaultParse[*Ipv4](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &Ipv4{fields.Vars, medik.LogLevelFromStr(config.Level), fields.Secret}, nil
 	})
 }
 
 func (r *Ipv4) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Ipv4) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		regexp := regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`)
 
 		if !regexp.MatchString(value) {
-			return invalidEnvVarStatus(name, r.Level, value, "value should be a valid IPv4 address")
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a valid IPv4 address")
 		}
 
 		return validEnvVarStatus(name)
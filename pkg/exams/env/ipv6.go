@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -13,26 +15,37 @@ import (
 // type: env.ipv6,
 // vars: []string
 type Ipv6 struct {
-	Vars  []string
-	Level int
+	Vars   []string
+	Level  int
+	Secret bool
 }
 
 func (r *Ipv6) Type() string {
 	return "env.ipv6"
 }
 
+func (r *Ipv6) Fields() interface{} {
+	return &VarsFields{}
+}
+
 func (r *Ipv6) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Ipv6](conf, func(config config.Exam) (exams.Exam, error) {
-		return &Ipv6{config.Vars, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &VarsFields{}
+
+	return DefaultParse[*Ipv6](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &Ipv6{fields.Vars, medik.LogLevelFromStr(config.Level), fields.Secret}, nil
 	})
 }
 
 func (r *Ipv6) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Ipv6) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		regexp := regexp.MustCompile(`^(([0-9a-fA-F]{1,4}:){7,7}[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,7}:|([0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,5}(:[0-9a-fA-F]{1,4}){1,2}|([0-9a-fA-F]{1,4}:){1,4}(:[0-9a-fA-F]{1,4}){1,3}|([0-9a-fA-F]{1,4}:){1,3}(:[0-9a-fA-F]{1,4}){1,4}|([0-9a-fA-F]{1,4}:){1,2}(:[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:((:[0-9a-fA-F]{1,4}){1,6})|:((:[0-9a-fA-F]{1,4}){1,7}|:)|fe80:(:[0-9a-fA-F]{0,4}){0,4}%[0-9a-zA-Z]{1,}|::(ffff(:0{1,4}){0,1}:){0,1}((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])|([0-9a-fA-F]{1,4}:){1,4}:((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9]))$`)
 
 		if !regexp.MatchString(value) {
-			return invalidEnvVarStatus(name, r.Level, value, "value should be a valid IPv6 address")
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a valid IPv6 address")
 		}
 
 		return validEnvVarStatus(name)
@@ -1,7 +1,10 @@
 package env
 
 import (
+	"context"
+
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -11,22 +14,33 @@ import (
 // type: env.is-set,
 // vars: []string
 type IsSet struct {
-	Vars  []string
-	Level int
+	Vars   []string
+	Level  int
+	Secret bool
 }
 
 func (r *IsSet) Type() string {
 	return "env.is-set"
 }
 
+func (r *IsSet) Fields() interface{} {
+	return &VarsFields{}
+}
+
 func (r *IsSet) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*IsSet](conf, func(config config.Exam) (exams.Exam, error) {
-		return &IsSet{config.Vars, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &VarsFields{}
+
+	return DefaultParse[*IsSet](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &IsSet{fields.Vars, medik.LogLevelFromStr(config.Level), fields.Secret}, nil
 	})
 }
 
 func (r *IsSet) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *IsSet) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		return validEnvVarStatus(name)
 	})
 }
@@ -1,8 +1,10 @@
 package env
 
 import (
+	"context"
 	"testing"
 
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/medik"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,7 +14,7 @@ func TestEnvIsSet(t *testing.T) {
 
 	// Test when environment variables are not set
 	report := exam.Examinate()
-	ok, header, body := report.Format(medik.WARNING)
+	ok, header, body := report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.ERROR, ok)
 	assert.NotEmpty(t, header)
 	assert.NotEmpty(t, body)
@@ -21,8 +23,19 @@ func TestEnvIsSet(t *testing.T) {
 	t.Setenv("VAR1", "value1")
 	t.Setenv("VAR2", "value2")
 	report = exam.Examinate()
-	ok, header, body = report.Format(medik.WARNING)
+	ok, header, body = report.Format(medik.WARNING, true)
 	assert.Equal(t, medik.OK, ok)
 	assert.NotEmpty(t, header)
 	assert.Empty(t, body)
 }
+
+func TestEnvIsSetSource(t *testing.T) {
+	t.Setenv("VAR1", "value1")
+	exam := &IsSet{Vars: []string{"VAR1", "VAR2"}, Level: medik.ERROR}
+
+	// Only the variables of the source are examined
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"VAR2": "value2"}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, medik.ERROR, report.Statuses[0].Lvl)
+	assert.Equal(t, medik.OK, report.Statuses[1].Lvl)
+}
@@ -0,0 +1,141 @@
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/jsonschema"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"gopkg.in/yaml.v3"
+)
+
+// Check if an environment variable is set to a JSON value
+// If `schema` (inline) or `schema-file` (a JSON or YAML file) is set, the value must match that JSON Schema
+// A relative `schema-file` is resolved against the directory of the config file
+//
+// type: env.json,
+// vars: []string,
+// schema: object,
+// schema-file: string
+type Json struct {
+	Vars   []string
+	Level  int
+	Schema *jsonschema.Schema
+	Secret bool
+}
+
+// The fields of the env.json and env.yaml exams
+type SchemaFields struct {
+	VarsFields `yaml:",inline"`
+	Schema     any    `yaml:"schema"`
+	SchemaFile string `yaml:"schema-file"`
+}
+
+func (r *Json) Type() string {
+	return "env.json"
+}
+
+func (r *Json) Fields() interface{} {
+	return &SchemaFields{}
+}
+
+func (r *Json) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &SchemaFields{}
+
+	return DefaultParse[*Json](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		schema, err := parseSchema(fields, conf.Dir, r.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		return &Json{fields.Vars, medik.LogLevelFromStr(conf.Level), schema, fields.Secret}, nil
+	})
+}
+
+func (r *Json) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Json) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		var decoded any
+
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			// The errors of the decoder quote parts of the value
+			if secret {
+				return EnvStatus{Lvl: r.Level, Var: name, Message: "is not valid JSON"}
+			}
+
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not valid JSON: " + err.Error()}
+		}
+
+		return schemaStatus(name, r.Level, r.Schema, decoded, secret)
+	})
+}
+
+// Returns the schema set by `schema` or `schema-file`, or nil if none is set
+// A relative `schema-file` is resolved against `dir`, the directory of the config file
+func parseSchema(fields *SchemaFields, dir, exam string) (*jsonschema.Schema, error) {
+	raw := fields.Schema
+
+	if fields.SchemaFile != "" {
+		if raw != nil {
+			return nil, &exams.FieldValueError{Field: "schema-file", Exam: exam, Value: fields.SchemaFile, Message: "can't be set together with `schema`"}
+		}
+
+		content, err := os.ReadFile(config.ResolvePath(dir, fields.SchemaFile))
+		if err != nil {
+			return nil, &exams.FieldValueError{Field: "schema-file", Exam: exam, Value: fields.SchemaFile, Message: err.Error()}
+		}
+
+		// YAML is a superset of JSON, so this reads both
+		if err := yaml.Unmarshal(content, &raw); err != nil {
+			return nil, &exams.FieldValueError{Field: "schema-file", Exam: exam, Value: fields.SchemaFile, Message: err.Error()}
+		}
+	}
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	schema, err := jsonschema.Compile(raw)
+	if err != nil {
+		field, value := "schema", "..."
+		if fields.SchemaFile != "" {
+			field, value = "schema-file", fields.SchemaFile
+		}
+
+		return nil, &exams.FieldValueError{Field: field, Exam: exam, Value: value, Message: err.Error()}
+	}
+
+	return schema, nil
+}
+
+// Returns the status of a decoded value, listing the JSON pointer of each violation of `schema`, if not nil
+// The parts of the value shown by the violations are left out if `secret` is set
+func schemaStatus(name string, level int, schema *jsonschema.Schema, value any, secret bool) EnvStatus {
+	if schema == nil {
+		return validEnvVarStatus(name)
+	}
+
+	violations := schema.Validate(value)
+	if len(violations) == 0 {
+		return validEnvVarStatus(name)
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		if secret {
+			violation.Value = ""
+		}
+
+		messages[i] = violation.String()
+	}
+
+	return EnvStatus{Lvl: level, Var: name, Message: "doesn't match the schema: " + strings.Join(messages, "; ")}
+}
@@ -0,0 +1,74 @@
+package env
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+var flagsSchema = map[string]interface{}{
+	"type":                 "object",
+	"required":             []string{"enabled"},
+	"additionalProperties": map[string]interface{}{"type": "boolean"},
+}
+
+func TestEnvJson(t *testing.T) {
+	exam, err := (&Json{}).Parse(config.NewExam("env.json", map[string]interface{}{"vars": []string{"A", "B", "C", "D"}, "schema": flagsSchema}))
+	assert.Nil(t, err)
+
+	env := environment.Map{"A": `{"enabled": true, "beta": false}`, "B": `{"beta": "yes"}`, "C": `{"enabled": tru`}
+	report := exam.(*Json).ExaminateEnv(context.Background(), env).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "doesn't match the schema: /beta: expected boolean, got string; /enabled: is required"},
+		{Lvl: medik.ERROR, Var: "C", Message: "is not valid JSON: unexpected end of JSON input"},
+		{Lvl: medik.ERROR, Var: "D", Message: "is not set"},
+	}, report.Statuses)
+
+	// Without a schema, any JSON value is valid
+	exam = &Json{Vars: []string{"A"}, Level: medik.ERROR}
+	report = exam.(*Json).ExaminateEnv(context.Background(), environment.Map{"A": `[1, "two"]`}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Level())
+
+	// The nested values of a secret aren't shown
+	schema := map[string]interface{}{"properties": map[string]interface{}{"k": map[string]interface{}{"enum": []string{"a"}}}}
+	exam, err = (&Json{}).Parse(config.NewExam("env.json", map[string]interface{}{"vars": []string{"A"}, "schema": schema, "secret": true}))
+	assert.Nil(t, err)
+
+	report = exam.(*Json).ExaminateEnv(context.Background(), environment.Map{"A": `{"k": "another_secret_value"}`}).(*EnvReport)
+	assert.Equal(t, `doesn't match the schema: /k: expected one of "a"`, report.Statuses[0].Message)
+}
+
+func TestEnvYaml(t *testing.T) {
+	schema := writeEnvFile(t, "schema.json", `{"type": "array", "items": {"type": "integer", "minimum": 1}}`)
+
+	exam, err := (&Yaml{}).Parse(config.NewExam("env.yaml", map[string]interface{}{"vars": []string{"A", "B"}, "schema-file": schema}))
+	assert.Nil(t, err)
+
+	report := exam.(*Yaml).ExaminateEnv(context.Background(), environment.Map{"A": "[1, 2]", "B": "- 1\n- 0\n- x"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "doesn't match the schema: /1: expected a number >= 1, got 0; /2: expected integer, got string"},
+	}, report.Statuses)
+
+	// The nested values of a secret aren't shown, like the ones matching the secret patterns
+	exam.(*Yaml).Vars = []string{"B_TOKEN"}
+	report = exam.(*Yaml).ExaminateEnv(context.Background(), environment.WithSecrets(environment.Map{"B_TOKEN": "[7, -42]"}, []string{"*_TOKEN"}, false)).(*EnvReport)
+	assert.Equal(t, "doesn't match the schema: /1: expected a number >= 1", report.Statuses[0].Message)
+}
+
+func TestEnvSchemaParse(t *testing.T) {
+	_, err := (&Json{}).Parse(config.NewExam("env.json", map[string]interface{}{"vars": []string{"A"}, "schema": map[string]interface{}{"type": "text"}}))
+	assert.Equal(t, "invalid value '...' for field `schema` in exam env.json: invalid schema at /type: unknown type text", err.Error())
+
+	_, err = (&Yaml{}).Parse(config.NewExam("env.yaml", map[string]interface{}{"vars": []string{"A"}, "schema-file": "missing.json"}))
+	assert.Equal(t, "invalid value 'missing.json' for field `schema-file` in exam env.yaml: open missing.json: no such file or directory", err.Error())
+
+	_, err = (&Json{}).Parse(config.NewExam("env.json", map[string]interface{}{"vars": []string{"A"}, "schema": flagsSchema, "schema-file": "schema.json"}))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,143 @@
+package env
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a JWT that is well formed and not expired. The signature isn't verified
+// Tokens without an `exp` claim are valid unless `require-exp` is set. Unsigned tokens (`alg: none`) are warnings
+// Tokens are never shown in the report, so they're secrets whether `secret` is set or not
+//
+// type: env.jwt,
+// vars: []string,
+// require-exp: bool
+type Jwt struct {
+	Vars       []string
+	Level      int
+	RequireExp bool
+}
+
+// The fields of an env.jwt exam
+type JwtFields struct {
+	VarsFields `yaml:",inline"`
+	RequireExp bool `yaml:"require-exp"`
+}
+
+func (r *Jwt) Type() string {
+	return "env.jwt"
+}
+
+func (r *Jwt) Fields() interface{} {
+	return &JwtFields{}
+}
+
+func (r *Jwt) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &JwtFields{}
+
+	return DefaultParse[*Jwt](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Jwt{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.RequireExp}, nil
+	})
+}
+
+func (r *Jwt) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Jwt) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	// Tokens are credentials, so they're always secrets
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, true, env, func(name, value string, secret bool) EnvStatus {
+		header, claims, err := decodeJwt(value)
+		if err != nil {
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not a valid JWT: " + err.Error()}
+		}
+
+		now := time.Now()
+
+		exp, hasExp, err := numericDate(claims, "exp")
+		switch {
+		case err != nil:
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not a valid JWT: " + err.Error()}
+		case !hasExp && r.RequireExp:
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "has no expiration (exp claim)"}
+		case hasExp && !now.Before(exp):
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "expired at " + exp.UTC().Format(time.RFC3339)}
+		}
+
+		nbf, hasNbf, err := numericDate(claims, "nbf")
+		switch {
+		case err != nil:
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not a valid JWT: " + err.Error()}
+		case hasNbf && now.Before(nbf):
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not valid before " + nbf.UTC().Format(time.RFC3339)}
+		}
+
+		if alg, _ := header["alg"].(string); strings.EqualFold(alg, "none") {
+			return EnvStatus{Lvl: medik.WARNING, Var: name, Message: "is not signed (alg is none)"}
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+// Decodes the header and the claims of a JWT in the compact form `header.payload.signature`
+func decodeJwt(token string) (map[string]any, map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("expected 3 parts separated by dots, got %v", len(parts))
+	}
+
+	header, err := decodeJwtPart(parts[0], "header")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if alg, ok := header["alg"].(string); !ok || alg == "" {
+		return nil, nil, fmt.Errorf("the header has no alg")
+	}
+
+	claims, err := decodeJwtPart(parts[1], "payload")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return header, claims, nil
+}
+
+func decodeJwtPart(part, name string) (map[string]any, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return nil, fmt.Errorf("the %v is not base64url", name)
+	}
+
+	object := map[string]any{}
+	if err := json.Unmarshal(decoded, &object); err != nil {
+		return nil, fmt.Errorf("the %v is not a JSON object", name)
+	}
+
+	return object, nil
+}
+
+// Returns the time of a NumericDate claim, like `exp`, and if it's set
+func numericDate(claims map[string]any, claim string) (time.Time, bool, error) {
+	value, ok := claims[claim]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("the %v claim should be a number of seconds", claim)
+	}
+
+	return time.Unix(int64(seconds), 0), true, nil
+}
@@ -0,0 +1,51 @@
+package env
+
+import (
+	"context"
+	"net"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a MAC address, like `00:1a:2b:3c:4d:5e`
+//
+// type: env.mac,
+// vars: []string
+type Mac struct {
+	Vars   []string
+	Level  int
+	Secret bool
+}
+
+func (r *Mac) Type() string {
+	return "env.mac"
+}
+
+func (r *Mac) Fields() interface{} {
+	return &VarsFields{}
+}
+
+func (r *Mac) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &VarsFields{}
+
+	return DefaultParse[*Mac](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		return &Mac{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Secret}, nil
+	})
+}
+
+func (r *Mac) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Mac) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		if _, err := net.ParseMAC(value); err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a MAC address like 00:1a:2b:3c:4d:5e")
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
@@ -0,0 +1,117 @@
+package env
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/dotenv"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if every variable of a template env file (like `.env.example`) is set
+// If `local` is set, the variables of that env file missing from the template are reported as warnings
+// Relative paths are resolved against the directory of the config file
+//
+// type: env.matches-template,
+// template: string,
+// local: string
+type MatchesTemplate struct {
+	Template string
+	Local    string
+	Level    int
+}
+
+// The fields of an env.matches-template exam
+type MatchesTemplateFields struct {
+	Template string `yaml:"template"`
+	Local    string `yaml:"local"`
+}
+
+func (r *MatchesTemplate) Type() string {
+	return "env.matches-template"
+}
+
+func (r *MatchesTemplate) Fields() interface{} {
+	return &MatchesTemplateFields{}
+}
+
+func (r *MatchesTemplate) Parse(conf config.Exam) (exams.Exam, error) {
+	if conf.Type != r.Type() {
+		return nil, &exams.WrongExamParserError{Source: conf.Type, Using: r.Type()}
+	}
+
+	fields := &MatchesTemplateFields{}
+	if err := conf.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if fields.Template == "" {
+		return nil, &exams.MissingFieldError{Field: "template", Exam: r.Type()}
+	}
+
+	return &MatchesTemplate{conf.Path(fields.Template), conf.Path(fields.Local), medik.LogLevelFromStr(conf.Level)}, nil
+}
+
+func (r *MatchesTemplate) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *MatchesTemplate) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	template, err := readEnvFile(r.Template)
+	if err != nil {
+		return &EnvReport{Type: r.Type(), Lvl: r.Level, Statuses: []EnvStatus{{Lvl: r.Level, Var: r.Template, Message: err.Error()}}}
+	}
+
+	report := DefaultExaminate(r.Type(), r.Level, sortedKeys(template), false, env, func(name, value string, secret bool) EnvStatus {
+		return validEnvVarStatus(name)
+	})
+
+	if r.Local == "" {
+		return report
+	}
+
+	local, err := readEnvFile(r.Local)
+	if err != nil {
+		report.Lvl = r.Level
+		report.Statuses = append(report.Statuses, EnvStatus{Lvl: r.Level, Var: r.Local, Message: err.Error()})
+		return report
+	}
+
+	level := min(medik.WARNING, r.Level)
+
+	for _, name := range sortedKeys(local) {
+		if _, ok := template[name]; ok {
+			continue
+		}
+
+		report.Statuses = append(report.Statuses, EnvStatus{Lvl: level, Var: name, Message: "is set in " + r.Local + " but missing from the template " + r.Template})
+		report.Lvl = max(report.Lvl, level)
+	}
+
+	return report
+}
+
+// Reads and parses an env file, without expanding variables it doesn't define
+func readEnvFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return dotenv.Parse(string(content))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,88 @@
+package env
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes an env file with `content` in a temporary directory and returns its path
+func writeEnvFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o644)
+	assert.Nil(t, err)
+
+	return path
+}
+
+func TestEnvMatchesTemplate(t *testing.T) {
+	template := writeEnvFile(t, ".env.example", "# Required\nAPI_KEY=\nDATABASE_URL=postgres://localhost\n")
+	exam := &MatchesTemplate{Template: template, Level: medik.ERROR}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "secret"}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "API_KEY", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "DATABASE_URL", Message: "is not set"},
+	}, report.Statuses)
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "secret", "DATABASE_URL": "postgres://db"}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Level())
+}
+
+func TestEnvMatchesTemplateLocal(t *testing.T) {
+	template := writeEnvFile(t, ".env.example", "API_KEY=\n")
+	local := writeEnvFile(t, ".env", "API_KEY=secret\nDEBUG=1\n")
+	exam := &MatchesTemplate{Template: template, Local: local, Level: medik.ERROR}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "secret"}).(*EnvReport)
+	assert.Equal(t, medik.WARNING, report.Level())
+	assert.Equal(t, EnvStatus{Lvl: medik.WARNING, Var: "DEBUG", Message: "is set in " + local + " but missing from the template " + template}, report.Statuses[1])
+
+	// The level of the exam caps the warnings
+	exam.Level = medik.OK
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "secret"}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Level())
+}
+
+func TestEnvMatchesTemplateInvalidFiles(t *testing.T) {
+	exam := &MatchesTemplate{Template: "/this/file/is/inexistent.env", Level: medik.WARNING}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{}).(*EnvReport)
+	assert.Equal(t, medik.WARNING, report.Level())
+	assert.Equal(t, "/this/file/is/inexistent.env", report.Statuses[0].Var)
+
+	exam = &MatchesTemplate{Template: writeEnvFile(t, ".env.example", "API_KEY\n"), Level: medik.ERROR}
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, "line 1: missing '=' after variable 'API_KEY'", report.Statuses[0].Message)
+
+	exam = &MatchesTemplate{Template: writeEnvFile(t, ".env.example", "API_KEY=\n"), Local: "/this/file/is/inexistent.env", Level: medik.ERROR}
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "x"}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+}
+
+func TestEnvMatchesTemplateParse(t *testing.T) {
+	exam := &MatchesTemplate{}
+
+	_, err := exam.Parse(config.Exam{Type: "env.is-set"})
+	assert.NotNil(t, err)
+
+	_, err = exam.Parse(config.NewExam("env.matches-template", map[string]interface{}{"local": ".env"}))
+	assert.Equal(t, "missing field `template` in exam env.matches-template", err.Error())
+
+	conf := config.NewExam("env.matches-template", map[string]interface{}{"template": ".env.example", "local": ".env"})
+	conf.Level = "warning"
+
+	parsed, err := exam.Parse(conf)
+	assert.Nil(t, err)
+	assert.Equal(t, &MatchesTemplate{Template: ".env.example", Local: ".env", Level: medik.WARNING}, parsed)
+}
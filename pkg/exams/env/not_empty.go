@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"strings"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -14,24 +16,35 @@ import (
 // type: env.not-empty
 // vars: []string
 type NotEmpty struct {
-	Vars  []string
-	Level int
+	Vars   []string
+	Level  int
+	Secret bool
 }
 
 func (r *NotEmpty) Type() string {
 	return "env.not-empty"
 }
 
+func (r *NotEmpty) Fields() interface{} {
+	return &VarsFields{}
+}
+
 func (r *NotEmpty) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*NotEmpty](conf, func(config config.Exam) (exams.Exam, error) {
-		return &NotEmpty{config.Vars, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &VarsFields{}
+
+	return DefaultParse[*NotEmpty](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &NotEmpty{fields.Vars, medik.LogLevelFromStr(config.Level), fields.Secret}, nil
 	})
 }
 
 func (r *NotEmpty) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *NotEmpty) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		if strings.TrimSpace(value) == "" {
-			return invalidEnvVarStatus(name, r.Level, value, "value must contain at least one non-whitespace character")
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value must contain at least one non-whitespace character")
 		}
 
 		return validEnvVarStatus(name)
@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -17,32 +19,49 @@ type Option struct {
 	Vars    []string
 	Level   int
 	Options map[string]bool
+	Secret  bool
+}
+
+// The fields of an env.options exam
+type OptionFields struct {
+	VarsFields `yaml:",inline"`
+	Options    []string `yaml:"options"`
 }
 
 func (r *Option) Type() string {
 	return "env.options"
 }
 
+func (r *Option) Fields() interface{} {
+	return &OptionFields{}
+}
+
 func (r *Option) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Option](conf, func(config config.Exam) (exams.Exam, error) {
-		if len(config.Options) == 0 {
+	fields := &OptionFields{}
+
+	return DefaultParse[*Option](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		if len(fields.Options) == 0 {
 			return nil, &exams.MissingFieldError{Field: "options", Exam: r.Type()}
 		}
 
 		options := make(map[string]bool)
 
-		for _, o := range config.Options {
+		for _, o := range fields.Options {
 			options[o] = true
 		}
 
-		return &Option{config.Vars, medik.LogLevelFromStr(config.Level), options}, nil
+		return &Option{fields.Vars, medik.LogLevelFromStr(config.Level), options, fields.Secret}, nil
 	})
 }
 
 func (r *Option) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Option) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		if _, ok := r.Options[value]; !ok {
-			return invalidEnvVarStatus(name, r.Level, value, r.ErrorMessage())
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.ErrorMessage())
 		}
 
 		return validEnvVarStatus(name)
@@ -0,0 +1,149 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a list of existing directories, like `PATH` or `PYTHONPATH`
+// The list is split on the separator of the OS (`:`, or `;` on Windows), and each entry gets its own status,
+// like `PATH[2]`. Duplicated and empty entries are warnings. The entries of a secret variable are masked
+// Each directory of `contains` must be in the list and, if `before` is set, come before that directory
+//
+// type: env.path-list,
+// vars: []string,
+// contains: []string,
+// before: string
+type PathList struct {
+	Vars     []string
+	Level    int
+	Contains []string
+	Before   string
+	Secret   bool
+}
+
+// The fields of an env.path-list exam
+type PathListFields struct {
+	VarsFields `yaml:",inline"`
+	Contains   []string `yaml:"contains"`
+	Before     string   `yaml:"before"`
+}
+
+func (r *PathList) Type() string {
+	return "env.path-list"
+}
+
+func (r *PathList) Fields() interface{} {
+	return &PathListFields{}
+}
+
+func (r *PathList) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &PathListFields{}
+
+	return DefaultParse[*PathList](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		if fields.Before != "" && len(fields.Contains) == 0 {
+			return nil, &exams.MissingFieldError{Field: "contains", Exam: r.Type()}
+		}
+
+		return &PathList{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Contains, fields.Before, fields.Secret}, nil
+	})
+}
+
+func (r *PathList) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+// Unlike DefaultExaminate, each variable gets a status for each of its entries
+func (r *PathList) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	report := &EnvReport{Type: r.Type(), Statuses: []EnvStatus{}}
+
+	for _, name := range r.Vars {
+		value, ok := env.LookupEnv(name)
+		if !ok {
+			report.Statuses = append(report.Statuses, unsetEnvVarStatus(name, r.Level))
+			continue
+		}
+
+		origin := environment.Origin(env, name)
+
+		for _, status := range r.validate(name, value, environment.IsSecret(env, name, r.Secret)) {
+			status.Lvl = min(status.Lvl, r.Level)
+			status.Origin = origin
+			report.Statuses = append(report.Statuses, status)
+		}
+	}
+
+	for _, status := range report.Statuses {
+		report.Lvl = max(report.Lvl, status.Lvl)
+	}
+
+	return report
+}
+
+// Returns the statuses of the entries of a list, followed by the ones of `contains`
+// The entries are masked in the messages if `secret` is set
+func (r *PathList) validate(name, value string, secret bool) []EnvStatus {
+	entries := filepath.SplitList(value)
+	if len(entries) == 0 {
+		return []EnvStatus{{Lvl: r.Level, Var: name, Message: "is empty"}}
+	}
+
+	statuses := []EnvStatus{}
+	// The first index of each directory, to find duplicates
+	seen := map[string]int{}
+
+	for i, entry := range entries {
+		key := fmt.Sprintf("%v[%v]", name, i)
+
+		if entry == "" {
+			statuses = append(statuses, EnvStatus{Lvl: medik.WARNING, Var: key, Message: "is empty, which means the current directory"})
+			continue
+		}
+
+		dir := filepath.Clean(entry)
+
+		shown := entry
+		if secret {
+			shown = MaskSecret(entry)
+		}
+
+		if first, ok := seen[dir]; ok {
+			statuses = append(statuses, EnvStatus{Lvl: medik.WARNING, Var: key, Message: fmt.Sprintf("'%v' is a duplicate of %v[%v]", shown, name, first)})
+			continue
+		}
+		seen[dir] = i
+
+		stat, err := os.Stat(entry)
+
+		switch {
+		case err != nil:
+			statuses = append(statuses, EnvStatus{Lvl: r.Level, Var: key, Message: fmt.Sprintf("'%v' doesn't exist", shown)})
+		case !stat.IsDir():
+			statuses = append(statuses, EnvStatus{Lvl: r.Level, Var: key, Message: fmt.Sprintf("'%v' is not a directory", shown)})
+		default:
+			statuses = append(statuses, EnvStatus{Lvl: medik.OK, Var: key, Message: fmt.Sprintf("'%v' is valid", shown)})
+		}
+	}
+
+	before, hasBefore := seen[filepath.Clean(r.Before)]
+
+	for _, dir := range r.Contains {
+		i, ok := seen[filepath.Clean(dir)]
+
+		switch {
+		case !ok:
+			statuses = append(statuses, EnvStatus{Lvl: r.Level, Var: name, Message: "should contain " + dir})
+		case r.Before != "" && hasBefore && i > before:
+			statuses = append(statuses, EnvStatus{Lvl: r.Level, Var: name, Message: fmt.Sprintf("should have %v before %v, but it's at %v[%v] after %v[%v]", dir, r.Before, name, i, name, before)})
+		}
+	}
+
+	return statuses
+}
@@ -0,0 +1,53 @@
+package env
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvPathList(t *testing.T) {
+	root := t.TempDir()
+	bin, local, file, missing := filepath.Join(root, "bin"), filepath.Join(root, "local"), filepath.Join(root, "file"), filepath.Join(root, "missing")
+	assert.Nil(t, os.Mkdir(bin, 0o755))
+	assert.Nil(t, os.Mkdir(local, 0o755))
+	assert.Nil(t, os.WriteFile(file, nil, 0o644))
+
+	list := strings.Join([]string{bin, "", file, missing, bin + "/", local}, string(os.PathListSeparator))
+	exam := &PathList{Vars: []string{"LIST", "UNSET"}, Level: medik.ERROR, Contains: []string{local, filepath.Join(root, "go")}, Before: bin}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"LIST": list}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "LIST[0]", Message: "'" + bin + "' is valid"},
+		{Lvl: medik.WARNING, Var: "LIST[1]", Message: "is empty, which means the current directory"},
+		{Lvl: medik.ERROR, Var: "LIST[2]", Message: "'" + file + "' is not a directory"},
+		{Lvl: medik.ERROR, Var: "LIST[3]", Message: "'" + missing + "' doesn't exist"},
+		{Lvl: medik.WARNING, Var: "LIST[4]", Message: "'" + bin + "/' is a duplicate of LIST[0]"},
+		{Lvl: medik.OK, Var: "LIST[5]", Message: "'" + local + "' is valid"},
+		{Lvl: medik.ERROR, Var: "LIST", Message: "should have " + local + " before " + bin + ", but it's at LIST[5] after LIST[0]"},
+		{Lvl: medik.ERROR, Var: "LIST", Message: "should contain " + filepath.Join(root, "go")},
+		{Lvl: medik.ERROR, Var: "UNSET", Message: "is not set"},
+	}, report.Statuses)
+
+	// Warnings are capped by the level of the exam
+	exam = &PathList{Vars: []string{"LIST"}, Level: medik.WARNING}
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"LIST": strings.Join([]string{local, bin, local}, string(os.PathListSeparator))}).(*EnvReport)
+	assert.Equal(t, medik.WARNING, report.Level())
+
+	// The entries of a secret are masked
+	exam = &PathList{Vars: []string{"LIST"}, Level: medik.ERROR, Secret: true}
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"LIST": missing}).(*EnvReport)
+	assert.Equal(t, "'"+MaskSecret(missing)+"' doesn't exist", report.Statuses[0].Message)
+	assert.NotContains(t, report.Statuses[0].Message, root)
+
+	_, err := (&PathList{}).Parse(config.NewExam("env.path-list", map[string]interface{}{"vars": []string{"PATH"}, "before": "/usr/bin"}))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,93 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is a TCP/UDP port, from 1 to 65535, optionally within a range
+// Privileged ports (below 1024) need root to be bound, so they're reported as warnings unless `privileged` is set
+//
+// type: env.port,
+// vars: []string,
+// min: int,
+// max: int,
+// privileged: bool
+type Port struct {
+	Vars       []string
+	Level      int
+	Range      Range[int]
+	Privileged bool
+	Secret     bool
+}
+
+// The fields of an env.port exam
+type PortFields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
+	Privileged  bool `yaml:"privileged"`
+}
+
+// The first port that doesn't need root to be bound
+const UnprivilegedPort = 1024
+
+func (r *Port) Type() string {
+	return "env.port"
+}
+
+func (r *Port) Fields() interface{} {
+	return &PortFields{}
+}
+
+func (r *Port) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &PortFields{}
+
+	return DefaultParse[*Port](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		bounds, err := parseRange(fields.RangeFields, r.Type(), false, parsePort)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Port{fields.Vars, medik.LogLevelFromStr(conf.Level), bounds, fields.Privileged, fields.Secret}, nil
+	})
+}
+
+func (r *Port) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Port) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		port, err := parsePort(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, err.Error())
+		}
+
+		if !r.Range.Contains(port) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.Range.ErrorMessage(strconv.Itoa))
+		}
+
+		if port < UnprivilegedPort && !r.Privileged {
+			return EnvStatus{Lvl: medik.WARNING, Var: name, Message: "is a privileged port, which needs root to be bound"}
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, errInvalidPort
+	}
+
+	return port, nil
+}
+
+var errInvalidPort = errors.New("value should be a port from 1 to 65535")
@@ -0,0 +1,82 @@
+package env
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+)
+
+// The `min` and `max` fields of the exams that check if a value is within a range
+// They're decoded as strings, so each exam parses them with its own syntax, like `1s` or `10MiB`
+type RangeFields struct {
+	Min *string `yaml:"min"`
+	Max *string `yaml:"max"`
+}
+
+// An inclusive range of values. Bounds that aren't set are unlimited
+type Range[T cmp.Ordered] struct {
+	Min,
+	Max T
+	HasMin,
+	HasMax bool
+}
+
+// Parses the bounds of a range using `parse`. If `required` is set, both bounds must be set
+func parseRange[T cmp.Ordered](fields RangeFields, exam string, required bool, parse func(string) (T, error)) (Range[T], error) {
+	r := Range[T]{}
+
+	bounds := []struct {
+		field string
+		raw   *string
+		value *T
+		set   *bool
+	}{
+		{"min", fields.Min, &r.Min, &r.HasMin},
+		{"max", fields.Max, &r.Max, &r.HasMax},
+	}
+
+	for _, bound := range bounds {
+		if bound.raw == nil {
+			if required {
+				return r, &exams.MissingFieldError{Field: bound.field, Exam: exam}
+			}
+			continue
+		}
+
+		value, err := parse(*bound.raw)
+		if err != nil {
+			return r, &exams.FieldValueError{Field: bound.field, Exam: exam, Value: *bound.raw, Message: err.Error()}
+		}
+
+		*bound.value, *bound.set = value, true
+	}
+
+	if r.HasMin && r.HasMax && r.Max < r.Min {
+		return r, &exams.FieldValueError{Field: "max", Exam: exam, Value: *fields.Max, Message: "should not be less than min"}
+	}
+
+	return r, nil
+}
+
+// Returns if `value` is within the range
+func (r Range[T]) Contains(value T) bool {
+	return (!r.HasMin || value >= r.Min) && (!r.HasMax || value <= r.Max)
+}
+
+// Returns the message of a value out of the range, formatting the bounds with `format`
+func (r Range[T]) ErrorMessage(format func(T) string) string {
+	switch {
+	case r.HasMin && r.HasMax:
+		return fmt.Sprintf("value should be in the range [%v,%v]", format(r.Min), format(r.Max))
+	case r.HasMin:
+		return "value should be at least " + format(r.Min)
+	}
+
+	return "value should be at most " + format(r.Max)
+}
+
+// Formats a bound with its default format
+func formatBound[T any](value T) string {
+	return fmt.Sprint(value)
+}
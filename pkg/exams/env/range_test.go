@@ -0,0 +1,108 @@
+package env
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	min, max := "1s", "1m"
+
+	bounds, err := parseRange(RangeFields{Min: &min}, "env.duration", false, time.ParseDuration)
+	assert.Nil(t, err)
+	assert.Equal(t, Range[time.Duration]{Min: time.Second, HasMin: true}, bounds)
+	assert.True(t, bounds.Contains(time.Hour))
+	assert.False(t, bounds.Contains(time.Millisecond))
+	assert.Equal(t, "value should be at least 1s", bounds.ErrorMessage(time.Duration.String))
+
+	bounds, err = parseRange(RangeFields{Min: &max, Max: &min}, "env.duration", false, time.ParseDuration)
+	assert.Equal(t, "invalid value '1s' for field `max` in exam env.duration: should not be less than min", err.Error())
+
+	_, err = parseRange(RangeFields{Max: &max}, "env.duration", true, time.ParseDuration)
+	assert.NotNil(t, err)
+}
+
+func TestEnvDuration(t *testing.T) {
+	exam, err := (&Duration{}).Parse(config.NewExam("env.duration", map[string]interface{}{"vars": []string{"A", "B", "C"}, "min": "1s", "max": "1m"}))
+	assert.Nil(t, err)
+
+	report := exam.(*Duration).ExaminateEnv(context.Background(), environment.Map{"A": "30s", "B": "2m", "C": "30"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "'2m' is not valid: value should be in the range [1s,1m0s]"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'30' is not valid: value should be a duration like 30s or 1h30m"},
+	}, report.Statuses)
+}
+
+func TestEnvSize(t *testing.T) {
+	exam, err := (&Size{}).Parse(config.NewExam("env.size", map[string]interface{}{"vars": []string{"A", "B", "C", "D"}, "max": "1GiB"}))
+	assert.Nil(t, err)
+
+	report := exam.(*Size).ExaminateEnv(context.Background(), environment.Map{"A": "10MiB", "B": "1.5 gib", "C": "512", "D": "10 bananas"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "B", Message: "'1.5 gib' is not valid: value should be at most 1GiB"},
+		{Lvl: medik.OK, Var: "C", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "D", Message: "'10 bananas' is not valid: value should be a size like 512, 10MB or 1.5GiB"},
+	}, report.Statuses)
+
+	size, err := ParseSize("1.5kB")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1500), size)
+	assert.Equal(t, "1500B", FormatSize(size))
+	assert.Equal(t, "2GB", FormatSize(2_000_000_000))
+
+	// 8192PiB is 2^63, one more than the largest int64
+	size, err = ParseSize("8191PiB")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8191)<<50, size)
+
+	_, err = ParseSize("8192PiB")
+	assert.EqualError(t, err, "value is too big")
+}
+
+func TestEnvBool(t *testing.T) {
+	exam, err := (&Bool{}).Parse(config.NewExam("env.bool", map[string]interface{}{"vars": []string{"A", "B", "C"}}))
+	assert.Nil(t, err)
+
+	report := exam.(*Bool).ExaminateEnv(context.Background(), environment.Map{"A": "Yes", "B": "off", "C": "maybe"}).(*EnvReport)
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.OK, Var: "B", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'maybe' is not valid: value should be one of [true t yes y on 1] or [false f no n off 0]"},
+	}, report.Statuses)
+
+	exam, err = (&Bool{}).Parse(config.NewExam("env.bool", map[string]interface{}{"vars": []string{"A"}, "true-values": []string{"enabled"}, "false-values": []string{"disabled"}}))
+	assert.Nil(t, err)
+	assert.Equal(t, medik.ERROR, exam.(*Bool).ExaminateEnv(context.Background(), environment.Map{"A": "yes"}).Level())
+
+	_, err = (&Bool{}).Parse(config.NewExam("env.bool", map[string]interface{}{"vars": []string{"A"}, "true-values": []string{"no"}}))
+	assert.NotNil(t, err)
+}
+
+func TestEnvPort(t *testing.T) {
+	exam, err := (&Port{}).Parse(config.NewExam("env.port", map[string]interface{}{"vars": []string{"A", "B", "C", "D"}, "max": 9000}))
+	assert.Nil(t, err)
+
+	report := exam.(*Port).ExaminateEnv(context.Background(), environment.Map{"A": "8080", "B": "80", "C": "9090", "D": "70000"}).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.OK, Var: "A", Message: "is valid"},
+		{Lvl: medik.WARNING, Var: "B", Message: "is a privileged port, which needs root to be bound"},
+		{Lvl: medik.ERROR, Var: "C", Message: "'9090' is not valid: value should be at most 9000"},
+		{Lvl: medik.ERROR, Var: "D", Message: "'70000' is not valid: value should be a port from 1 to 65535"},
+	}, report.Statuses)
+
+	exam.(*Port).Vars, exam.(*Port).Privileged = []string{"A"}, true
+	report = exam.(*Port).ExaminateEnv(context.Background(), environment.Map{"A": "80"}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Level())
+
+	_, err = (&Port{}).Parse(config.NewExam("env.port", map[string]interface{}{"vars": []string{"A"}, "min": 0}))
+	assert.Equal(t, "invalid value '0' for field `min` in exam env.port: value should be a port from 1 to 65535", err.Error())
+}
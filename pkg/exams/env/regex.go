@@ -1,10 +1,12 @@
 package env
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
@@ -16,35 +18,52 @@ import (
 // vars: []string,
 // regex: string
 type Regex struct {
-	Vars  []string
-	Level int
-	Regex *regexp.Regexp
+	Vars   []string
+	Level  int
+	Regex  *regexp.Regexp
+	Secret bool
+}
+
+// The fields of an env.regex exam
+type RegexFields struct {
+	VarsFields `yaml:",inline"`
+	Regex      string `yaml:"regex"`
 }
 
 func (r *Regex) Type() string {
 	return "env.regex"
 }
 
+func (r *Regex) Fields() interface{} {
+	return &RegexFields{}
+}
+
 func (r *Regex) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Regex](conf, func(config config.Exam) (exams.Exam, error) {
-		if config.Regex == "" {
+	fields := &RegexFields{}
+
+	return DefaultParse[*Regex](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		if fields.Regex == "" {
 			return nil, &exams.MissingFieldError{Field: "regex", Exam: r.Type()}
 		}
 
-		regexp, rerr := regexp.Compile(config.Regex)
+		regexp, rerr := regexp.Compile(fields.Regex)
 
 		if rerr != nil {
-			return nil, &exams.FieldValueError{Field: "regex", Exam: r.Type(), Value: config.Regex, Message: rerr.Error()}
+			return nil, &exams.FieldValueError{Field: "regex", Exam: r.Type(), Value: fields.Regex, Message: rerr.Error()}
 		}
 
-		return &Regex{config.Vars, medik.LogLevelFromStr(config.Level), regexp}, nil
+		return &Regex{fields.Vars, medik.LogLevelFromStr(config.Level), regexp, fields.Secret}, nil
 	})
 }
 
 func (r *Regex) Examinate() exams.Report {
-	return DefaultExaminate(r.Type(), r.Level, r.Vars, func(name, value string) EnvStatus {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Regex) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
 		if !r.Regex.MatchString(value) {
-			return invalidEnvVarStatus(name, r.Level, value, r.ErrorMessage())
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.ErrorMessage())
 		}
 
 		return validEnvVarStatus(name)
@@ -0,0 +1,186 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable holds a strong secret: long enough, random enough, with the required
+// classes of characters and not a known placeholder. Values are never shown in the report
+// A relative `template` is resolved against the directory of the config file
+//
+// type: env.secret,
+// vars: []string,
+// min-length: int,
+// min-entropy: float,
+// require: []string,
+// deny: []string,
+// template: string
+type Secret struct {
+	Vars       []string
+	Level      int
+	MinLength  int
+	MinEntropy float64
+	Require    []string
+	Deny       []string
+	Template   string
+}
+
+// The fields of an env.secret exam
+type SecretFields struct {
+	VarsFields `yaml:",inline"`
+	MinLength  *int     `yaml:"min-length"`
+	MinEntropy *float64 `yaml:"min-entropy"`
+	Require    []string `yaml:"require"`
+	Deny       []string `yaml:"deny"`
+	Template   string   `yaml:"template"`
+}
+
+// The defaults of an env.secret exam
+const (
+	DefaultSecretMinLength  = 16
+	DefaultSecretMinEntropy = 3.0
+)
+
+// The placeholders rejected by an env.secret exam, besides the ones in `deny`. They're compared ignoring case
+var DefaultSecretDeny = []string{"changeme", "change-me", "change_me", "xxx", "todo", "secret", "password", "example", "placeholder", "test", "default"}
+
+// The classes of characters an env.secret exam can require
+var secretClasses = map[string]func(rune) bool{
+	"lower":  unicode.IsLower,
+	"upper":  unicode.IsUpper,
+	"digit":  unicode.IsDigit,
+	"symbol": func(c rune) bool { return !unicode.IsLetter(c) && !unicode.IsDigit(c) && !unicode.IsSpace(c) },
+}
+
+func (r *Secret) Type() string {
+	return "env.secret"
+}
+
+func (r *Secret) Fields() interface{} {
+	return &SecretFields{}
+}
+
+func (r *Secret) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &SecretFields{}
+
+	return DefaultParse[*Secret](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		exam := &Secret{
+			Vars:       fields.Vars,
+			Level:      medik.LogLevelFromStr(conf.Level),
+			MinLength:  DefaultSecretMinLength,
+			MinEntropy: DefaultSecretMinEntropy,
+			Require:    fields.Require,
+			Deny:       append(append([]string{}, DefaultSecretDeny...), fields.Deny...),
+			Template:   conf.Path(fields.Template),
+		}
+
+		if fields.MinLength != nil {
+			if *fields.MinLength < 0 {
+				return nil, &exams.FieldValueError{Field: "min-length", Exam: r.Type(), Value: fmt.Sprint(*fields.MinLength), Message: "should not be negative"}
+			}
+			exam.MinLength = *fields.MinLength
+		}
+
+		if fields.MinEntropy != nil {
+			if *fields.MinEntropy < 0 {
+				return nil, &exams.FieldValueError{Field: "min-entropy", Exam: r.Type(), Value: fmt.Sprint(*fields.MinEntropy), Message: "should not be negative"}
+			}
+			exam.MinEntropy = *fields.MinEntropy
+		}
+
+		for _, class := range fields.Require {
+			if _, ok := secretClasses[class]; !ok {
+				return nil, &exams.FieldValueError{Field: "require", Exam: r.Type(), Value: class, Message: "should be one of lower, upper, digit or symbol"}
+			}
+		}
+
+		return exam, nil
+	})
+}
+
+func (r *Secret) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Secret) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	var template map[string]string
+
+	if r.Template != "" {
+		var err error
+		if template, err = readEnvFile(r.Template); err != nil {
+			return &EnvReport{Type: r.Type(), Lvl: r.Level, Statuses: []EnvStatus{{Lvl: r.Level, Var: r.Template, Message: err.Error()}}}
+		}
+	}
+
+	// The statuses below never include the value, so there's nothing to mask
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, false, env, func(name, value string, secret bool) EnvStatus {
+		if problem := r.check(value, template[name]); problem != "" {
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not a strong secret: " + problem}
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+// Returns why `value` isn't a strong secret, or an empty string if it is
+// `example` is the value of the variable in the template, if any
+func (r *Secret) check(value, example string) string {
+	if value == "" {
+		return "it's empty"
+	}
+
+	if example != "" && value == example {
+		return "it's the same as in " + r.Template
+	}
+
+	for _, placeholder := range r.Deny {
+		if strings.EqualFold(value, placeholder) {
+			return "it's a known placeholder"
+		}
+	}
+
+	if length := len([]rune(value)); length < r.MinLength {
+		return fmt.Sprintf("it has %v characters, expected at least %v", length, r.MinLength)
+	}
+
+	if entropy := ShannonEntropy(value); entropy < r.MinEntropy {
+		return fmt.Sprintf("its entropy is %.2f bits per character, expected at least %.2f", entropy, r.MinEntropy)
+	}
+
+	for _, class := range r.Require {
+		if !strings.ContainsFunc(value, secretClasses[class]) {
+			return "it has no " + class + " characters"
+		}
+	}
+
+	return ""
+}
+
+// Returns the estimated Shannon entropy of `value`, in bits per character
+func ShannonEntropy(value string) float64 {
+	counts := map[rune]int{}
+	total := 0
+
+	for _, c := range value {
+		counts[c]++
+		total++
+	}
+
+	entropy := 0.0
+
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
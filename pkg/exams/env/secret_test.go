@@ -0,0 +1,74 @@
+package env
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvSecret(t *testing.T) {
+	exam, err := (&Secret{}).Parse(config.NewExam("env.secret", map[string]interface{}{
+		"vars":    []string{"A", "B", "C", "D", "E", "F", "G"},
+		"require": []string{"digit"},
+		"deny":    []string{"hunter2hunter2hunter2"},
+	}))
+	assert.Nil(t, err)
+
+	env := environment.Map{
+		"A": "CHANGEME",
+		"B": "hunter2hunter2hunter2",
+		"C": "s3cr3t",
+		"D": "aaaaaaaaaaaaaaaaaaaa",
+		"E": "kfjqpwoeirutyzmxncbv",
+		"F": "kfj5pwo7iru9yzm1ncb3",
+	}
+
+	report := exam.(*Secret).ExaminateEnv(context.Background(), env).(*EnvReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, []EnvStatus{
+		{Lvl: medik.ERROR, Var: "A", Message: "is not a strong secret: it's a known placeholder"},
+		{Lvl: medik.ERROR, Var: "B", Message: "is not a strong secret: it's a known placeholder"},
+		{Lvl: medik.ERROR, Var: "C", Message: "is not a strong secret: it has 6 characters, expected at least 16"},
+		{Lvl: medik.ERROR, Var: "D", Message: "is not a strong secret: its entropy is 0.00 bits per character, expected at least 3.00"},
+		{Lvl: medik.ERROR, Var: "E", Message: "is not a strong secret: it has no digit characters"},
+		{Lvl: medik.OK, Var: "F", Message: "is valid"},
+		{Lvl: medik.ERROR, Var: "G", Message: "is not set"},
+	}, report.Statuses)
+}
+
+func TestEnvSecretTemplate(t *testing.T) {
+	template := writeEnvFile(t, ".env.example", "API_KEY=kfj5pwo7iru9yzm1ncb3\n")
+	exam := &Secret{Vars: []string{"API_KEY"}, Level: medik.WARNING, Template: template}
+
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "kfj5pwo7iru9yzm1ncb3"}).(*EnvReport)
+	assert.Equal(t, "is not a strong secret: it's the same as in "+template, report.Statuses[0].Message)
+
+	report = exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "9vn2mx8qz7lw3kd5"}).(*EnvReport)
+	assert.Equal(t, medik.OK, report.Level())
+}
+
+func TestEnvSecretShortValue(t *testing.T) {
+	exam := &Secret{Vars: []string{"API_KEY"}, Level: medik.ERROR, MinLength: 16}
+
+	// A short value is also part of the message, which is left as it is
+	report := exam.ExaminateEnv(context.Background(), environment.Map{"API_KEY": "t"}).(*EnvReport)
+	assert.Equal(t, "is not a strong secret: it has 1 characters, expected at least 16", report.Statuses[0].Message)
+}
+
+func TestEnvSecretParse(t *testing.T) {
+	_, err := (&Secret{}).Parse(config.NewExam("env.secret", map[string]interface{}{"vars": []string{"A"}, "require": []string{"emoji"}}))
+	assert.NotNil(t, err)
+
+	_, err = (&Secret{}).Parse(config.NewExam("env.secret", map[string]interface{}{"vars": []string{"A"}, "min-length": -1}))
+	assert.NotNil(t, err)
+}
+
+func TestShannonEntropy(t *testing.T) {
+	assert.Equal(t, 0.0, ShannonEntropy("aaaa"))
+	assert.Equal(t, 1.0, ShannonEntropy("abab"))
+	assert.Equal(t, 2.0, ShannonEntropy("abcd"))
+}
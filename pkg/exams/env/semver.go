@@ -0,0 +1,88 @@
+package env
+
+import (
+	"context"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/OJarrisonn/medik/pkg/semver"
+)
+
+// Check if an environment variable is set to a semantic version, like `1.21.3`, that matches `constraint`, if set
+// Versions must strictly follow the semver spec, unless `loose` is set to accept forms like `v1.21`
+//
+// type: env.semver,
+// vars: []string,
+// constraint: string,
+// loose: bool
+type Semver struct {
+	Vars       []string
+	Level      int
+	Constraint *semver.Constraint
+	Loose      bool
+	Secret     bool
+}
+
+// The fields of an env.semver exam
+type SemverFields struct {
+	VarsFields `yaml:",inline"`
+	Constraint string `yaml:"constraint"`
+	Loose      bool   `yaml:"loose"`
+}
+
+func (r *Semver) Type() string {
+	return "env.semver"
+}
+
+func (r *Semver) Fields() interface{} {
+	return &SemverFields{}
+}
+
+func (r *Semver) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &SemverFields{}
+
+	return DefaultParse[*Semver](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		exam := &Semver{Vars: fields.Vars, Level: medik.LogLevelFromStr(conf.Level), Loose: fields.Loose, Secret: fields.Secret}
+
+		if fields.Constraint != "" {
+			constraint, err := semver.ParseConstraint(fields.Constraint)
+			if err != nil {
+				return nil, &exams.FieldValueError{Field: "constraint", Exam: r.Type(), Value: fields.Constraint, Message: err.Error()}
+			}
+			exam.Constraint = &constraint
+		}
+
+		return exam, nil
+	})
+}
+
+func (r *Semver) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Semver) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		parse := semver.ParseStrict
+		if r.Loose {
+			parse = semver.Parse
+		}
+
+		version, err := parse(value)
+		if err != nil {
+			// The error quotes the invalid part of the version
+			if secret {
+				return invalidEnvVarStatus(name, r.Level, value, secret, "value should be a semantic version like 1.2.3")
+			}
+
+			return EnvStatus{Lvl: r.Level, Var: name, Message: err.Error()}
+		}
+
+		if r.Constraint != nil && !r.Constraint.Check(version) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should match "+r.Constraint.String())
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
@@ -0,0 +1,125 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is a size in bytes like `512`, `10MB` or `1.5GiB`, optionally within a range
+// Min and Max are inclusive sizes, and both are optional
+//
+// type: env.size,
+// vars: []string,
+// min: size,
+// max: size
+type Size struct {
+	Vars   []string
+	Level  int
+	Range  Range[int64]
+	Secret bool
+}
+
+// The fields of an env.size exam
+type SizeFields struct {
+	VarsFields  `yaml:",inline"`
+	RangeFields `yaml:",inline"`
+}
+
+// The units of sizes, ignoring case. kB, MB, ... are powers of 1000, while KiB, MiB, ... are powers of 1024
+var sizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+func (r *Size) Type() string {
+	return "env.size"
+}
+
+func (r *Size) Fields() interface{} {
+	return &SizeFields{}
+}
+
+func (r *Size) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &SizeFields{}
+
+	return DefaultParse[*Size](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		bounds, err := parseRange(fields.RangeFields, r.Type(), false, ParseSize)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Size{fields.Vars, medik.LogLevelFromStr(conf.Level), bounds, fields.Secret}, nil
+	})
+}
+
+func (r *Size) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Size) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		size, err := ParseSize(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, err.Error())
+		}
+
+		if !r.Range.Contains(size) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, r.Range.ErrorMessage(FormatSize))
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+// Parses a size like `512`, `10MB` or `1.5GiB` into bytes
+func ParseSize(value string) (int64, error) {
+	s := strings.TrimSpace(value)
+	i := strings.IndexFunc(s, func(c rune) bool { return (c < '0' || c > '9') && c != '.' })
+	if i == -1 {
+		i = len(s)
+	}
+
+	num, err := strconv.ParseFloat(s[:i], 64)
+	unit, ok := sizeUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+
+	if err != nil || !ok {
+		return 0, fmt.Errorf("value should be a size like 512, 10MB or 1.5GiB")
+	}
+
+	// MaxInt64 rounds up to 2^63 as a float, which doesn't fit in an int64 anymore
+	bytes := num * float64(unit)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("value is too big")
+	}
+
+	return int64(bytes), nil
+}
+
+// Formats a size with the largest unit that divides it, like `10MiB`, `2GB` or `1500B`
+func FormatSize(bytes int64) string {
+	for _, unit := range []string{"PiB", "PB", "TiB", "TB", "GiB", "GB", "MiB", "MB", "KiB", "kB"} {
+		if size := sizeUnits[strings.ToLower(unit)]; bytes != 0 && bytes%size == 0 {
+			return strconv.FormatInt(bytes/size, 10) + unit
+		}
+	}
+
+	return strconv.FormatInt(bytes, 10) + "B"
+}
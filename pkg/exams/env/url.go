@@ -0,0 +1,171 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to an absolute URL, like `postgres://db:5432/app`
+// Unlike env.hostname, the URL must have a scheme and, unless `require-host` is false, a valid host
+// URLs with credentials are warnings unless `allow-credentials` is set. Passwords are never shown, and
+// neither is any part of the URL of a secret variable
+//
+// type: env.url,
+// vars: []string,
+// schemes: []string,
+// require-host: bool,
+// ports: []int,
+// path-prefix: string,
+// allowed-hosts: []string,
+// allow-credentials: bool
+type Url struct {
+	Vars             []string
+	Level            int
+	Schemes          []string
+	RequireHost      bool
+	Ports            []int
+	PathPrefix       string
+	AllowedHosts     []string
+	AllowCredentials bool
+	Secret           bool
+}
+
+// The fields of an env.url exam
+type UrlFields struct {
+	VarsFields       `yaml:",inline"`
+	Schemes          []string `yaml:"schemes"`
+	RequireHost      *bool    `yaml:"require-host"`
+	Ports            []int    `yaml:"ports"`
+	PathPrefix       string   `yaml:"path-prefix"`
+	AllowedHosts     []string `yaml:"allowed-hosts"`
+	AllowCredentials bool     `yaml:"allow-credentials"`
+}
+
+func (r *Url) Type() string {
+	return "env.url"
+}
+
+func (r *Url) Fields() interface{} {
+	return &UrlFields{}
+}
+
+func (r *Url) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &UrlFields{}
+
+	return DefaultParse[*Url](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		requireHost := fields.RequireHost == nil || *fields.RequireHost
+
+		// Patterns are matched ignoring case, like hostnames
+		hosts := make([]string, len(fields.AllowedHosts))
+		for i, pattern := range fields.AllowedHosts {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, &exams.FieldValueError{Field: "allowed-hosts", Exam: r.Type(), Value: pattern, Message: err.Error()}
+			}
+			hosts[i] = strings.ToLower(pattern)
+		}
+
+		for _, port := range fields.Ports {
+			if _, err := parsePort(strconv.Itoa(port)); err != nil {
+				return nil, &exams.FieldValueError{Field: "ports", Exam: r.Type(), Value: strconv.Itoa(port), Message: err.Error()}
+			}
+		}
+
+		return &Url{
+			fields.Vars,
+			medik.LogLevelFromStr(conf.Level),
+			fields.Schemes,
+			requireHost,
+			fields.Ports,
+			fields.PathPrefix,
+			hosts,
+			fields.AllowCredentials,
+			fields.Secret,
+		}, nil
+	})
+}
+
+func (r *Url) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Url) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		url, err := neturl.Parse(value)
+		if err != nil || url.Scheme == "" {
+			return invalidEnvVarStatus(name, r.Level, value, secret, "value should be an absolute URL like scheme://host/path")
+		}
+
+		// Don't show the password, even if the variable isn't a secret. The whole value of a secret
+		// is masked instead
+		if message := r.check(url, secret); message != "" {
+			shown := url.Redacted()
+			if secret {
+				shown = value
+			}
+
+			return invalidEnvVarStatus(name, r.Level, shown, secret, message)
+		}
+
+		if url.User != nil && !r.AllowCredentials {
+			return EnvStatus{Lvl: medik.WARNING, Var: name, Message: "has credentials, which are better kept in a secret store"}
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+// Returns why `url` breaks the constraints of the exam, or an empty string if it doesn't
+// The host isn't part of the message if `secret` is set
+func (r *Url) check(url *neturl.URL, secret bool) string {
+	if len(r.Schemes) > 0 && !containsFold(r.Schemes, url.Scheme) {
+		return fmt.Sprintf("scheme should be one of %v", r.Schemes)
+	}
+
+	host := url.Hostname()
+
+	if r.RequireHost && !isHost(host) {
+		if host == "" {
+			return "value should have a host"
+		}
+
+		if secret {
+			return "host is not an IP address or a valid hostname"
+		}
+
+		return "'" + host + "' is not an IP address or a valid hostname"
+	}
+
+	if len(r.AllowedHosts) > 0 && !slices.ContainsFunc(r.AllowedHosts, func(pattern string) bool {
+		ok, _ := path.Match(pattern, strings.ToLower(host))
+		return ok
+	}) {
+		return fmt.Sprintf("host should match one of %v", r.AllowedHosts)
+	}
+
+	if port := url.Port(); port != "" {
+		num, err := parsePort(port)
+		if err != nil {
+			return err.Error()
+		}
+
+		if len(r.Ports) > 0 && !slices.Contains(r.Ports, num) {
+			return fmt.Sprintf("port should be one of %v", r.Ports)
+		}
+	}
+
+	if r.PathPrefix != "" && !strings.HasPrefix(url.Path, r.PathPrefix) {
+		return "path should start with " + r.PathPrefix
+	}
+
+	return ""
+}
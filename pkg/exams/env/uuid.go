@@ -0,0 +1,99 @@
+package env
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if an environment variable is set to a UUID in its canonical form, like `123e4567-e89b-42d3-a456-426614174000`
+// If `versions` is set, only UUIDs of those versions (1 to 8) are valid
+//
+// type: env.uuid,
+// vars: []string,
+// versions: []int
+type Uuid struct {
+	Vars     []string
+	Level    int
+	Versions []int
+	Secret   bool
+}
+
+// The fields of an env.uuid exam
+type UuidFields struct {
+	VarsFields `yaml:",inline"`
+	Versions   []int `yaml:"versions"`
+}
+
+func (r *Uuid) Type() string {
+	return "env.uuid"
+}
+
+func (r *Uuid) Fields() interface{} {
+	return &UuidFields{}
+}
+
+func (r *Uuid) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &UuidFields{}
+
+	return DefaultParse[*Uuid](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		for _, version := range fields.Versions {
+			if version < 1 || version > 8 {
+				return nil, &exams.FieldValueError{Field: "versions", Exam: r.Type(), Value: strconv.Itoa(version), Message: "UUID versions go from 1 to 8"}
+			}
+		}
+
+		return &Uuid{fields.Vars, medik.LogLevelFromStr(conf.Level), fields.Versions, fields.Secret}, nil
+	})
+}
+
+func (r *Uuid) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Uuid) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		version, err := uuidVersion(value)
+		if err != nil {
+			return invalidEnvVarStatus(name, r.Level, value, secret, err.Error())
+		}
+
+		if len(r.Versions) > 0 && !slices.Contains(r.Versions, version) {
+			return invalidEnvVarStatus(name, r.Level, value, secret, fmt.Sprintf("value should be a UUID of version %v, got version %v", r.Versions, version))
+		}
+
+		return validEnvVarStatus(name)
+	})
+}
+
+// Returns the version of a UUID in its canonical form, or 0 for the nil UUID
+func uuidVersion(value string) (int, error) {
+	groups := strings.Split(value, "-")
+	if len(groups) != 5 || len(groups[0]) != 8 || len(groups[1]) != 4 || len(groups[2]) != 4 || len(groups[3]) != 4 || len(groups[4]) != 12 {
+		return 0, fmt.Errorf("value should be a UUID like 123e4567-e89b-42d3-a456-426614174000")
+	}
+
+	bytes, err := hex.DecodeString(strings.Join(groups, ""))
+	if err != nil {
+		return 0, fmt.Errorf("value should only have hexadecimal digits and dashes")
+	}
+
+	if slices.Max(bytes) == 0 {
+		return 0, nil
+	}
+
+	// Versions are defined for the variant of RFC 9562, whose two most significant bits are 10
+	if bytes[8]&0xc0 != 0x80 {
+		return 0, fmt.Errorf("value should be a UUID of the RFC 9562 variant")
+	}
+
+	return int(bytes[6] >> 4), nil
+}
@@ -0,0 +1,69 @@
+package env
+
+import (
+	"context"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/jsonschema"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"gopkg.in/yaml.v3"
+)
+
+// Check if an environment variable is set to a YAML value
+// If `schema` (inline) or `schema-file` (a JSON or YAML file) is set, the value must match that JSON Schema
+// A relative `schema-file` is resolved against the directory of the config file
+//
+// type: env.yaml,
+// vars: []string,
+// schema: object,
+// schema-file: string
+type Yaml struct {
+	Vars   []string
+	Level  int
+	Schema *jsonschema.Schema
+	Secret bool
+}
+
+func (r *Yaml) Type() string {
+	return "env.yaml"
+}
+
+func (r *Yaml) Fields() interface{} {
+	return &SchemaFields{}
+}
+
+func (r *Yaml) Parse(conf config.Exam) (exams.Exam, error) {
+	fields := &SchemaFields{}
+
+	return DefaultParse[*Yaml](conf, fields, func(conf config.Exam) (exams.Exam, error) {
+		schema, err := parseSchema(fields, conf.Dir, r.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		return &Yaml{fields.Vars, medik.LogLevelFromStr(conf.Level), schema, fields.Secret}, nil
+	})
+}
+
+func (r *Yaml) Examinate() exams.Report {
+	return r.ExaminateEnv(context.Background(), environment.Process)
+}
+
+func (r *Yaml) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	return DefaultExaminate(r.Type(), r.Level, r.Vars, r.Secret, env, func(name, value string, secret bool) EnvStatus {
+		var decoded any
+
+		if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+			// The errors of the decoder quote parts of the value
+			if secret {
+				return EnvStatus{Lvl: r.Level, Var: name, Message: "is not valid YAML"}
+			}
+
+			return EnvStatus{Lvl: r.Level, Var: name, Message: "is not valid YAML: " + err.Error()}
+		}
+
+		return schemaStatus(name, r.Level, r.Schema, jsonschema.Normalize(decoded), secret)
+	})
+}
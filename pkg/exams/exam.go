@@ -2,7 +2,11 @@
 package exams
 
 import (
+	"context"
+	"time"
+
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 )
 
 // Interface that describes a rule
@@ -23,9 +27,84 @@ type Exam interface {
 	Examinate() Report
 }
 
+// An Exam that can be interrupted through a context.Context
+// Exams that do blocking work (running commands, dialing services, etc) should implement it so they can be
+// stopped on timeouts or cancellation. Exams that don't are adapted by Examinate
+type ContextExam interface {
+	Exam
+
+	// ExaminateContext checks if a rule is being enforced, stopping as soon as possible when ctx is done
+	ExaminateContext(ctx context.Context) Report
+}
+
+// An Exam that reads environment variables
+// It's examinated against the environment of the run, which isn't always the one of the process (like when
+// it comes from an env file). Its Examinate method uses the process environment
+type EnvExam interface {
+	Exam
+
+	// ExaminateEnv checks if a rule is being enforced by `env`, stopping as soon as possible when ctx is done
+	ExaminateEnv(ctx context.Context, env environment.Source) Report
+}
+
+// An Exam with fields specific to its type, decoded with config.Exam.Decode
+// Fields returns a pointer to a zero value of the struct the fields are decoded into. Strict config
+// decoding uses it, through RegisterFields, to find fields the exam doesn't accept. It may be called on a
+// zero value of the exam
+type FieldsExam interface {
+	Exam
+
+	Fields() interface{}
+}
+
+// Same as ExaminateEnv, using the process environment
+func Examinate(ctx context.Context, exam Exam) (Report, error) {
+	return ExaminateEnv(ctx, exam, environment.Process)
+}
+
+// Runs an exam until it finishes or the context is done, whichever comes first
+// EnvExams are examinated against `env`, other exams can only see the process environment
+// Returns the context error if the exam was interrupted, in which case the report must be ignored
+// Exams that don't implement ContextExam or EnvExam run in their own goroutine, which is abandoned if the context is done
+func ExaminateEnv(ctx context.Context, exam Exam, env environment.Source) (Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if e, ok := exam.(EnvExam); ok {
+		report := e.ExaminateEnv(ctx, env)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return report, nil
+	}
+
+	if e, ok := exam.(ContextExam); ok {
+		report := e.ExaminateContext(ctx)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return report, nil
+	}
+
+	done := make(chan Report, 1)
+	go func() {
+		done <- exam.Examinate()
+	}()
+
+	select {
+	case report := <-done:
+		return report, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // Returns the Parse method from an Exam
 // Since this method is decoupled from the values stored in the struct
-func ExamParse[E Exam]() func(config config.Exam) (Exam, error) {
+func ExamParse[E Exam]() Parser {
 	var e E
 	return e.Parse
 }
@@ -42,8 +121,50 @@ type Report interface {
 
 	// Format the report to a printable string
 	// Verbose indicates if non-error messages should be included
+	// NoColor disables the colors of the output
 	// Returns the report level, a string with the report header and a string with the report body
-	Format(verbosity int) (int, string, string)
+	Format(verbosity int, noColor bool) (int, string, string)
+
+	// Returns the structured data of the report
+	// This is used by machine-readable outputs (like JSON) that can't rely on formatted strings
+	Data() ReportData
+}
+
+// The structured contents of a Report, independent of the exam category that produced it
+// Protocol is empty for exams defined at the top-level of the config
+// Duration is how long the exam took to run, it's zero if it wasn't measured
+type ReportData struct {
+	Exam     string
+	Protocol string
+	Level    int
+	Statuses []Status
+	Duration time.Duration
+}
+
+// A Report as produced by a run of the exams
+// It behaves exactly like the wrapped Report, but its data is tagged with the protocol the exam
+// was declared in (empty for top-level exams) and how long it took to run
+type RunReport struct {
+	Report
+	Protocol string
+	Duration time.Duration
+}
+
+func (r *RunReport) Data() ReportData {
+	data := r.Report.Data()
+	data.Protocol = r.Protocol
+	data.Duration = r.Duration
+
+	return data
+}
+
+// A single entry of a Report. The Key identifies what was checked (an env var, a path, etc)
+// Origin is where the checked value comes from, like the env file of a variable. It's empty if unknown
+type Status struct {
+	Key     string
+	Message string
+	Level   int
+	Origin  string
 }
 
 // An error to describe a strange scenario where the wrong exam parser was called
@@ -78,3 +199,42 @@ type FieldValueError struct {
 func (e *FieldValueError) Error() string {
 	return "invalid value '" + e.Value + "' for field `" + e.Field + "` in exam " + e.Exam + ": " + e.Message
 }
+
+// An error to describe a field that doesn't exist in a config
+// Suggestion is a known field with a similar name, if any
+type UnknownFieldError struct {
+	Field,
+	Exam,
+	Suggestion string
+}
+
+func (e *UnknownFieldError) Error() string {
+	message := "unknown field `" + e.Field + "`"
+	if e.Exam != "" {
+		message += " in exam " + e.Exam
+	}
+
+	if e.Suggestion != "" {
+		message += ", did you mean `" + e.Suggestion + "`?"
+	}
+
+	return message
+}
+
+// An error to describe a field that exists, but isn't used by the exam it was set on
+// Suggestion is a field of the exam with a similar name, if any
+type UnusedFieldError struct {
+	Field,
+	Exam,
+	Suggestion string
+}
+
+func (e *UnusedFieldError) Error() string {
+	message := "field `" + e.Field + "` is not used by exam " + e.Exam
+
+	if e.Suggestion != "" {
+		message += ", did you mean `" + e.Suggestion + "`?"
+	}
+
+	return message
+}
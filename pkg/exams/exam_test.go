@@ -0,0 +1,80 @@
+package exams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReport struct{}
+
+func (r *fakeReport) Level() int                                               { return 0 }
+func (r *fakeReport) Format(verbosity int, noColor bool) (int, string, string) { return 0, "", "" }
+func (r *fakeReport) Data() ReportData                                         { return ReportData{} }
+
+// An exam that takes `delay` to run and can't be interrupted
+type slowExam struct {
+	delay time.Duration
+}
+
+func (e *slowExam) Type() string                           { return "test.slow" }
+func (e *slowExam) Parse(config config.Exam) (Exam, error) { return e, nil }
+func (e *slowExam) Examinate() Report                      { time.Sleep(e.delay); return &fakeReport{} }
+
+// An exam that waits for its context
+type contextExam struct {
+	slowExam
+	interrupted bool
+}
+
+func (e *contextExam) ExaminateContext(ctx context.Context) Report {
+	select {
+	case <-time.After(e.delay):
+	case <-ctx.Done():
+		e.interrupted = true
+	}
+
+	return &fakeReport{}
+}
+
+func TestExaminateAdapter(t *testing.T) {
+	report, err := Examinate(context.Background(), &slowExam{delay: time.Millisecond})
+	assert.Nil(t, err)
+	assert.NotNil(t, report)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	report, err = Examinate(ctx, &slowExam{delay: time.Second})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, report)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestExaminateContextExam(t *testing.T) {
+	exam := &contextExam{slowExam: slowExam{delay: time.Millisecond}}
+	report, err := Examinate(context.Background(), exam)
+	assert.Nil(t, err)
+	assert.NotNil(t, report)
+	assert.False(t, exam.interrupted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Already cancelled contexts don't even start the exam
+	exam = &contextExam{slowExam: slowExam{delay: time.Second}}
+	_, err = Examinate(ctx, exam)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, exam.interrupted)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = Examinate(ctx, exam)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, exam.interrupted)
+}
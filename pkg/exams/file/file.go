@@ -19,16 +19,26 @@ func (r *FileReport) Level() int {
 	return r.Lvl
 }
 
-func (r *FileReport) Format(verbosity int) (int, string, string) {
+func (r *FileReport) Format(verbosity int, noColor bool) (int, string, string) {
 	statuses := ""
 
 	for _, status := range r.Statuses {
 		if status.Lvl >= verbosity {
-			statuses += format.ReportStatus(status.Path, status.Message, status.Lvl) + "\n"
+			statuses += format.ReportStatus(status.Path, status.Message, status.Lvl, noColor) + "\n"
 		}
 	}
 
-	return r.Lvl, format.ReportHeader(r.Type, r.Lvl), statuses
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), statuses
+}
+
+func (r *FileReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Statuses))
+
+	for i, status := range r.Statuses {
+		statuses[i] = exams.Status{Key: status.Path, Message: status.Message, Level: status.Lvl}
+	}
+
+	return exams.ReportData{Exam: r.Type, Level: r.Lvl, Statuses: statuses}
 }
 
 // A status from a part of the execution of a `file.*` exam
@@ -38,29 +48,34 @@ type FileStatus struct {
 	Message string
 }
 
-var parsers = map[string]func(config config.Exam) (exams.Exam, error){
-	exams.ExamType[*Path]():       exams.ExamParse[*Path](),
-	exams.ExamType[*IsFile]():     exams.ExamParse[*IsFile](),
-	exams.ExamType[*IsDir]():      exams.ExamParse[*IsDir](),
-	exams.ExamType[*IsEmpty]():    exams.ExamParse[*IsEmpty](),
-	exams.ExamType[*IsNotEmpty](): exams.ExamParse[*IsNotEmpty](),
+// Zero values of the exams of the category
+var all = []exams.Exam{
+	&Path{},
+	&IsFile{},
+	&IsDir{},
+	&IsEmpty{},
+	&IsNotEmpty{},
+}
+
+var parsers = exams.NewParsers(all...)
+
+func init() {
+	exams.MustRegister("file", GetParser)
+	exams.RegisterFields(all...)
 }
 
 // Function to get a parser for a given type `env.*`
 // The type is the part after `env.` in the exams.Exam type
 // Returns the parser and a boolean indicating if the parser was found
-func GetParser(ty string) (func(config config.Exam) (exams.Exam, error), bool) {
-	if parser, ok := parsers[ty]; ok {
-		return parser, ok
-	}
-
-	return nil, false
+func GetParser(ty string) (exams.Parser, bool) {
+	return parsers.Get(ty)
 }
 
 // Default implementation for Examinate method of exams.Exam. It checks for the existence of the environment
 // variables in `vars`. For those who exist, it validates the value using the `validate` function which should
 // return a boolean (valid or not) and an error if not valid. Those who are not set are considered invalid and
 // append an UnsetEnvVarError to the errors slice. If no errors are found, it returns true and nil.
+// Nothing is written outside of the returned report, so it can run in parallel for the same paths
 func DefaultExaminate(exam string, logLevel int, paths []string, validate func(path string, stat os.FileInfo) FileStatus) *FileReport {
 	statuses := []FileStatus{}
 	level := 0
@@ -88,14 +103,35 @@ func DefaultExaminate(exam string, logLevel int, paths []string, validate func(p
 	return &FileReport{Type: exam, Lvl: level, Statuses: statuses}
 }
 
-func DefaultParse[E exams.Exam](config config.Exam, f func(config config.Exam) (exams.Exam, error)) (exams.Exam, error) {
+// The fields of the `file.*` exams that only need a list of paths
+// Exams with more fields embed it inline
+type PathsFields struct {
+	Paths []string `yaml:"paths"`
+}
+
+func (f *PathsFields) PathList() []string {
+	return f.Paths
+}
+
+// The fields of a `file.*` exam, which always have a list of paths
+type Fields interface {
+	PathList() []string
+}
+
+// Default implementation for Parse method of exams.Exam. It checks the type of the exam, decodes its
+// specific fields into `fields` and checks the list of paths isn't empty before calling `f`
+func DefaultParse[E exams.Exam](config config.Exam, fields Fields, f func(config config.Exam) (exams.Exam, error)) (exams.Exam, error) {
 	var e E
 	ty := e.Type()
 	if config.Type != ty {
 		return nil, &exams.WrongExamParserError{Source: config.Type, Using: ty}
 	}
 
-	if len(config.Paths) == 0 {
+	if err := config.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if len(fields.PathList()) == 0 {
 		return nil, &exams.MissingFieldError{Field: "paths", Exam: ty}
 	}
 
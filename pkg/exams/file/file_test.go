@@ -0,0 +1,40 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Run with -race to catch exams sharing state between runs
+func TestDefaultExaminateConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte("medik"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	exam := &IsEmpty{Paths: []string{dir, file, filepath.Join(dir, "missing")}}
+	expected := exam.Examinate()
+
+	reports := make([]interface{}, 16)
+	wg := sync.WaitGroup{}
+
+	for i := range reports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i] = exam.Examinate()
+		}()
+	}
+
+	wg.Wait()
+
+	for _, report := range reports {
+		if !reflect.DeepEqual(report, expected) {
+			t.Errorf("got %v, want %v", report, expected)
+		}
+	}
+}
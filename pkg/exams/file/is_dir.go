@@ -20,12 +20,19 @@ func (i *IsDir) Type() string {
 	return "file.is-dir"
 }
 
+// Fields returns a pointer to the struct the fields specific to the exam are decoded into
+func (i *IsDir) Fields() interface{} {
+	return &PathsFields{}
+}
+
 // Try parses an []exams.Exam from a config.Exam
 // Returns an error if the config.Exam is invalid
 // This method is always called on a zero value of the implementing struct
 func (i *IsDir) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*IsDir](conf, func(config config.Exam) (exams.Exam, error) {
-		return &IsDir{config.Paths, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &PathsFields{}
+
+	return DefaultParse[*IsDir](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &IsDir{fields.Paths, medik.LogLevelFromStr(config.Level)}, nil
 	})
 }
 
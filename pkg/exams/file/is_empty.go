@@ -20,12 +20,19 @@ func (i *IsEmpty) Type() string {
 	return "file.is-empty"
 }
 
+// Fields returns a pointer to the struct the fields specific to the exam are decoded into
+func (i *IsEmpty) Fields() interface{} {
+	return &PathsFields{}
+}
+
 // Try parses an []exams.Exam from a config.Exam
 // Returns an error if the config.Exam is invalid
 // This method is always called on a zero value of the implementing struct
 func (i *IsEmpty) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*IsEmpty](conf, func(config config.Exam) (exams.Exam, error) {
-		return &IsEmpty{config.Paths, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &PathsFields{}
+
+	return DefaultParse[*IsEmpty](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &IsEmpty{fields.Paths, medik.LogLevelFromStr(config.Level)}, nil
 	})
 }
 
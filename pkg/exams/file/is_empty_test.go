@@ -19,3 +19,10 @@ func TestIsEmpty_Parse(t *testing.T) {
 		t.Errorf("got %v, want nil", got)
 	}
 }
+
+func TestIsNotEmpty_Type(t *testing.T) {
+	i := &IsNotEmpty{}
+	if got, want := i.Type(), "file.is-not-empty"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -20,12 +20,19 @@ func (i *IsFile) Type() string {
 	return "file.is-file"
 }
 
+// Fields returns a pointer to the struct the fields specific to the exam are decoded into
+func (i *IsFile) Fields() interface{} {
+	return &PathsFields{}
+}
+
 // Try parses an []exams.Exam from a config.Exam
 // Returns an error if the config.Exam is invalid
 // This method is always called on a zero value of the implementing struct
 func (i *IsFile) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*IsFile](conf, func(config config.Exam) (exams.Exam, error) {
-		return &IsFile{config.Paths, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &PathsFields{}
+
+	return DefaultParse[*IsFile](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &IsFile{fields.Paths, medik.LogLevelFromStr(config.Level)}, nil
 	})
 }
 
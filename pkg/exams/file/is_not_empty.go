@@ -17,15 +17,22 @@ type IsNotEmpty struct {
 // This is used to parse the config.Exam by selecting the correct exam parser
 // This method is always called on a zero value of the implementing struct
 func (i *IsNotEmpty) Type() string {
-	return "file.is-empty"
+	return "file.is-not-empty"
+}
+
+// Fields returns a pointer to the struct the fields specific to the exam are decoded into
+func (i *IsNotEmpty) Fields() interface{} {
+	return &PathsFields{}
 }
 
 // Try parses an []exams.Exam from a config.Exam
 // Returns an error if the config.Exam is invalid
 // This method is always called on a zero value of the implementing struct
 func (i *IsNotEmpty) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*IsNotEmpty](conf, func(config config.Exam) (exams.Exam, error) {
-		return &IsNotEmpty{config.Paths, medik.LogLevelFromStr(config.Level)}, nil
+	fields := &PathsFields{}
+
+	return DefaultParse[*IsNotEmpty](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &IsNotEmpty{fields.Paths, medik.LogLevelFromStr(config.Level)}, nil
 	})
 }
 
@@ -15,6 +15,12 @@ type Path struct {
 	Exists bool
 }
 
+// The fields of a file.path exam
+type PathFields struct {
+	PathsFields `yaml:",inline"`
+	Exists      bool `yaml:"exists"`
+}
+
 // Type returns the type of the exam
 // This is used to parse the config.Exam by selecting the correct exam parser
 // This method is always called on a zero value of the implementing struct
@@ -22,12 +28,19 @@ func (p *Path) Type() string {
 	return "file.path"
 }
 
+// Fields returns a pointer to the struct the fields specific to the exam are decoded into
+func (p *Path) Fields() interface{} {
+	return &PathFields{}
+}
+
 // Try parses an []exams.Exam from a config.Exam
 // Returns an error if the config.Exam is invalid
 // This method is always called on a zero value of the implementing struct
 func (p *Path) Parse(conf config.Exam) (exams.Exam, error) {
-	return DefaultParse[*Path](conf, func(config config.Exam) (exams.Exam, error) {
-		return &Path{config.Paths, medik.LogLevelFromStr(config.Level), config.Exists}, nil
+	fields := &PathFields{}
+
+	return DefaultParse[*Path](conf, fields, func(config config.Exam) (exams.Exam, error) {
+		return &Path{fields.Paths, medik.LogLevelFromStr(config.Level), fields.Exists}, nil
 	})
 }
 
@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+)
+
+// An exam run by a plugin
+// Input is the exam config encoded as JSON, which is written to the plugin stdin
+type Exam struct {
+	Plugin  *Plugin
+	Ty      string
+	Level   int
+	Timeout time.Duration
+	Input   []byte
+}
+
+func (e *Exam) Type() string {
+	return e.Ty
+}
+
+// Plugin exams are parsed by Plugin.GetParser, since their type depends on the plugin category
+func (e *Exam) Parse(conf config.Exam) (exams.Exam, error) {
+	if e.Plugin == nil {
+		return nil, &exams.WrongExamParserError{Source: conf.Type, Using: "plugin"}
+	}
+
+	return e.Plugin.parse(conf)
+}
+
+func (e *Exam) Examinate() exams.Report {
+	return e.ExaminateContext(context.Background())
+}
+
+func (e *Exam) ExaminateContext(ctx context.Context) exams.Report {
+	return e.ExaminateEnv(ctx, environment.Process)
+}
+
+// Runs the plugin with the variables of `env` as its environment
+func (e *Exam) ExaminateEnv(ctx context.Context, env environment.Source) exams.Report {
+	ctx, cancel := context.WithTimeout(ctx, e.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, e.Plugin.Path)
+	command.Stdin = bytes.NewReader(e.Input)
+	command.Env = env.Environ()
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	command.WaitDelay = time.Second
+
+	err := command.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return e.failed(fmt.Sprintf("timed out after %v", e.Timeout))
+	case ctx.Err() != nil:
+		return e.failed("cancelled: " + ctx.Err().Error())
+	case errors.As(err, &exitErr):
+		return e.failed(fmt.Sprintf("exited with code %d: %v", exitErr.ExitCode(), truncate(stderr.String())))
+	case err != nil:
+		return e.failed(err.Error())
+	}
+
+	report, err := decodeReport(e.Ty, e.Level, stdout.Bytes())
+	if err != nil {
+		return e.failed(fmt.Sprintf("malformed output: %v (output: '%v')", err, truncate(stdout.String())))
+	}
+
+	return report
+}
+
+// Creates the report of an exam whose plugin couldn't produce a report
+func (e *Exam) failed(message string) *PluginReport {
+	return &PluginReport{
+		Type:     e.Ty,
+		Lvl:      e.Level,
+		Statuses: []PluginStatus{{Lvl: e.Level, Key: e.Plugin.Path, Message: "plugin " + message}},
+	}
+}
@@ -0,0 +1,190 @@
+// This package runs exams implemented by external executables, the plugins
+//
+// A plugin handles every exam of a category, like `acme.*`. For each exam, the plugin is run with the
+// exam config encoded as JSON on its stdin, and must write its report as JSON to its stdout:
+//
+//	{"level": "warning", "statuses": [{"key": "vpn0", "message": "is down", "level": "warning"}]}
+//
+// Levels are `ok`, `warning` or `error`. The level of the report is optional and defaults to the highest
+// level of its statuses. Plugins that exit with a non-zero status or write a malformed report fail the exam
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/format"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// The timeout used to run a plugin when its exam doesn't set `timeout`
+const DefaultTimeout = 30 * time.Second
+
+// The maximum amount of bytes of a plugin output shown in an error message
+const MaxOutputLength = 512
+
+// An executable that runs the exams of a category
+type Plugin struct {
+	Category string
+	Path     string
+}
+
+// Returns a parser for the exams of the plugin category. Every type of the category is accepted, since
+// only the plugin knows which ones it implements
+func (p *Plugin) GetParser(ty string) (exams.Parser, bool) {
+	if !strings.HasPrefix(ty, p.Category+".") {
+		return nil, false
+	}
+
+	return p.parse, true
+}
+
+func (p *Plugin) parse(conf config.Exam) (exams.Exam, error) {
+	fields := map[string]interface{}{}
+	if err := conf.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	// The plugin always gets the common fields, even when they're not set
+	fields["exam"] = conf.Type
+	fields["level"] = strings.ToLower(medik.LogLevel(medik.LogLevelFromStr(conf.Level)))
+
+	input, err := json.Marshal(fields)
+	if err != nil {
+		return nil, &exams.FieldValueError{Field: "exam", Exam: conf.Type, Value: conf.Type, Message: "can't encode the exam as JSON: " + err.Error()}
+	}
+
+	timeout := DefaultTimeout
+	if conf.Timeout != "" {
+		timeout, err = time.ParseDuration(conf.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, &exams.FieldValueError{Field: "timeout", Exam: conf.Type, Value: conf.Timeout, Message: "expected a positive duration like 10s"}
+		}
+	}
+
+	return &Exam{Plugin: p, Ty: conf.Type, Level: medik.LogLevelFromStr(conf.Level), Timeout: timeout, Input: input}, nil
+}
+
+// A report that is returned from a plugin exam
+type PluginReport struct {
+	Type     string
+	Lvl      int
+	Statuses []PluginStatus
+}
+
+// A status from a part of the execution of a plugin exam
+type PluginStatus struct {
+	Lvl     int
+	Key     string
+	Message string
+}
+
+func (r *PluginReport) Level() int {
+	return r.Lvl
+}
+
+func (r *PluginReport) Format(verbosity int, noColor bool) (int, string, string) {
+	statuses := ""
+
+	for _, status := range r.Statuses {
+		if status.Lvl >= verbosity {
+			statuses += format.ReportStatus(status.Key, status.Message, status.Lvl, noColor) + "\n"
+		}
+	}
+
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), statuses
+}
+
+func (r *PluginReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Statuses))
+
+	for i, status := range r.Statuses {
+		statuses[i] = exams.Status{Key: status.Key, Message: status.Message, Level: status.Lvl}
+	}
+
+	return exams.ReportData{Exam: r.Type, Level: r.Lvl, Statuses: statuses}
+}
+
+// The report written by a plugin on its stdout
+type output struct {
+	Level    *string `json:"level"`
+	Statuses []struct {
+		Key     string  `json:"key"`
+		Message string  `json:"message"`
+		Level   *string `json:"level"`
+	} `json:"statuses"`
+}
+
+// Decodes the report written by a plugin. Levels above `maxLevel` are lowered to it
+func decodeReport(exam string, maxLevel int, raw []byte) (*PluginReport, error) {
+	var out output
+
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	if err := decoder.Decode(&out); err != nil {
+		return nil, fmt.Errorf("expected a JSON report: %v", err)
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("expected a single JSON report, but found more output after it")
+	}
+
+	if out.Statuses == nil {
+		return nil, fmt.Errorf("missing field `statuses`")
+	}
+
+	report := &PluginReport{Type: exam}
+
+	for i, s := range out.Statuses {
+		if s.Level == nil {
+			return nil, fmt.Errorf("missing field `level` in statuses[%d]", i)
+		}
+
+		level, err := parseLevel(*s.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level in statuses[%d]: %v", i, err)
+		}
+
+		level = min(level, maxLevel)
+		report.Lvl = max(report.Lvl, level)
+		report.Statuses = append(report.Statuses, PluginStatus{Lvl: level, Key: s.Key, Message: s.Message})
+	}
+
+	if out.Level != nil {
+		level, err := parseLevel(*out.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report level: %v", err)
+		}
+
+		report.Lvl = min(level, maxLevel)
+	}
+
+	return report, nil
+}
+
+// Parses a level, unlike medik.LogLevelFromStr unknown levels are an error
+func parseLevel(raw string) (int, error) {
+	switch strings.ToLower(raw) {
+	case "ok":
+		return medik.OK, nil
+	case "warning":
+		return medik.WARNING, nil
+	case "error":
+		return medik.ERROR, nil
+	default:
+		return 0, fmt.Errorf("unknown level '%v', expected ok, warning or error", raw)
+	}
+}
+
+func truncate(output string) string {
+	output = strings.TrimSpace(output)
+
+	if len(output) > MaxOutputLength {
+		return output[:MaxOutputLength] + "... (truncated)"
+	}
+
+	return output
+}
@@ -0,0 +1,118 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates a fake plugin running the shell `script` and returns it
+func fakePlugin(t *testing.T, script string) *Plugin {
+	path := filepath.Join(t.TempDir(), "medik-plugin")
+
+	err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755)
+	assert.Nil(t, err)
+
+	return &Plugin{Category: "acme", Path: path}
+}
+
+// Parses an exam of the `acme` category using the plugin
+func parsePlugin(t *testing.T, p *Plugin, level string, fields map[string]interface{}) *Exam {
+	conf := config.NewExam("acme.vpn", fields)
+	conf.Level = level
+
+	parse, ok := p.GetParser(conf.Type)
+	assert.True(t, ok)
+
+	exam, err := parse(conf)
+	assert.Nil(t, err)
+
+	return exam.(*Exam)
+}
+
+func TestPluginGetParser(t *testing.T) {
+	p := &Plugin{Category: "acme", Path: "true"}
+
+	_, ok := p.GetParser("acme.anything")
+	assert.True(t, ok)
+
+	_, ok = p.GetParser("acmeish.anything")
+	assert.False(t, ok)
+}
+
+func TestPluginReport(t *testing.T) {
+	// The statuses echo part of the input, to check the exam config was sent
+	p := fakePlugin(t, `input=$(cat)
+case "$input" in
+  *'"exam":"acme.vpn","level":"warning","vars":["FOO"]'*) key=got-input ;;
+  *) key=missing-input ;;
+esac
+echo '{"statuses": [{"key": "'$key'", "message": "is up", "level": "ok"}, {"key": "vpn1", "message": "is down", "level": "ERROR"}]}'`)
+
+	report := parsePlugin(t, p, "warning", map[string]interface{}{"vars": []string{"FOO"}}).Examinate().(*PluginReport)
+
+	assert.Equal(t, "acme.vpn", report.Type)
+	assert.Equal(t, medik.WARNING, report.Level())
+	assert.Equal(t, []PluginStatus{
+		{Lvl: medik.OK, Key: "got-input", Message: "is up"},
+		{Lvl: medik.WARNING, Key: "vpn1", Message: "is down"},
+	}, report.Statuses)
+}
+
+func TestPluginReportLevel(t *testing.T) {
+	p := fakePlugin(t, `echo '{"level": "warning", "statuses": []}'`)
+
+	report := parsePlugin(t, p, "error", nil).Examinate()
+	assert.Equal(t, medik.WARNING, report.Level())
+}
+
+func TestPluginFailures(t *testing.T) {
+	cases := map[string]string{
+		`echo 'not json'`: "plugin malformed output: expected a JSON report",
+		`echo '{"statuses": [{"key": "a", "message": "b"}]}'`:  "missing field `level` in statuses[0]",
+		`echo '{"statuses": [{"key": "a", "level": "fine"}]}'`: "unknown level 'fine'",
+		`echo '{"level": "ok"}'`:                               "missing field `statuses`",
+		`echo '{"statuses": []}'; echo '{"statuses": []}'`:     "found more output after it",
+		`echo 'vpn tool not installed' >&2; exit 3`:            "plugin exited with code 3: vpn tool not installed",
+	}
+
+	for script, message := range cases {
+		p := fakePlugin(t, script)
+		report := parsePlugin(t, p, "warning", nil).Examinate().(*PluginReport)
+
+		assert.Equal(t, medik.WARNING, report.Level(), script)
+		assert.Len(t, report.Statuses, 1, script)
+		assert.Contains(t, report.Statuses[0].Message, message, script)
+	}
+}
+
+func TestPluginTimeout(t *testing.T) {
+	p := fakePlugin(t, `sleep 5`)
+
+	exam := parsePlugin(t, p, "error", nil)
+	exam.Timeout = 100 * time.Millisecond
+
+	report := exam.Examinate().(*PluginReport)
+	assert.Equal(t, medik.ERROR, report.Level())
+	assert.Equal(t, "plugin timed out after 100ms", report.Statuses[0].Message)
+}
+
+func TestPluginInvalidTimeout(t *testing.T) {
+	p := &Plugin{Category: "acme", Path: "true"}
+
+	conf := config.NewExam("acme.vpn", nil)
+	conf.Timeout = "soon"
+
+	_, err := p.parse(conf)
+	assert.Equal(t, "invalid value 'soon' for field `timeout` in exam acme.vpn: expected a positive duration like 10s", err.Error())
+
+	conf.Timeout = ""
+	exam, err := p.parse(conf)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultTimeout, exam.(*Exam).Timeout)
+}
@@ -0,0 +1,166 @@
+package exams
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+)
+
+// A function that parses a config.Exam into an Exam
+type Parser func(config config.Exam) (Exam, error)
+
+// A function to get the parser of an exam type of a category, like `env.is-set` for the `env` category
+// Returns the parser and a boolean indicating if the parser was found
+type CategoryParser func(ty string) (Parser, bool)
+
+// The parsers of the exams of a category, by exam type
+type Parsers map[string]Parser
+
+// Returns the parser of an exam type, it can be used as the CategoryParser of a category
+func (p Parsers) Get(ty string) (Parser, bool) {
+	parser, ok := p[ty]
+	return parser, ok
+}
+
+// Creates the Parsers of the given exams, which should be zero values like `&IsSet{}`
+// Panics with a *RegisterError if two exams have the same type, since only one of them could be parsed
+// Meant to be called while initializing a package, like MustRegister
+func NewParsers(exs ...Exam) Parsers {
+	parsers := Parsers{}
+
+	for _, e := range exs {
+		ty := e.Type()
+		if _, ok := parsers[ty]; ok {
+			category, _, _ := strings.Cut(ty, ".")
+			panic(&RegisterError{Category: category, Message: "exam type '" + ty + "' is declared twice"})
+		}
+
+		parsers[ty] = e.Parse
+	}
+
+	return parsers
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]CategoryParser{}
+	// The fields accepted by each exam type, by exam type
+	fields = map[string]interface{}{}
+)
+
+// Registers the parser of the exams of a category, like `env`. Every exam type whose name starts with
+// `category.` is parsed by it. Built-in categories are registered like any other, so a category can
+// only be registered once. Returns a *RegisterError if the category is invalid or already registered
+func Register(category string, parser CategoryParser) error {
+	if category == "" || strings.ContainsAny(category, ". \t\n") {
+		return &RegisterError{Category: category, Message: "category names can't be empty or have dots or spaces"}
+	}
+
+	if parser == nil {
+		return &RegisterError{Category: category, Message: "parser is nil"}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[category]; ok {
+		return &RegisterError{Category: category, Message: "category is already registered"}
+	}
+
+	registry[category] = parser
+
+	return nil
+}
+
+// Same as Register, but panics on error. Meant to be called from the `init` function of a package
+func MustRegister(category string, parser CategoryParser) {
+	if err := Register(category, parser); err != nil {
+		panic(err)
+	}
+}
+
+// Returns the parser for a given type from the registered categories
+// A type is a string in the format `category.kind` which identifies which exam will be parsed
+// Returns the parser and a boolean indicating if the parser was found
+func GetParser(ty string) (Parser, bool) {
+	category, _, _ := strings.Cut(ty, ".")
+
+	registryMu.RLock()
+	parser, ok := registry[category]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	return parser(ty)
+}
+
+// Registers the fields accepted by the given exams, which should be zero values like `&IsSet{}`
+// Strict config decoding uses them to check the keys of an exam even if it can't be parsed, and to tell
+// keys that no exam accepts apart from keys accepted by other exams. Exams that aren't FieldsExams are ignored
+func RegisterFields(exs ...Exam) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, e := range exs {
+		if f, ok := e.(FieldsExam); ok {
+			fields[e.Type()] = f.Fields()
+		}
+	}
+}
+
+// Returns the fields accepted by an exam type, as returned by its Fields method
+// Returns the fields and a boolean indicating if they were registered with RegisterFields
+func ExamFields(ty string) (interface{}, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	f, ok := fields[ty]
+	return f, ok
+}
+
+// Returns the fields accepted by every exam type registered with RegisterFields, sorted by exam type
+func AllExamFields() []interface{} {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]string, 0, len(fields))
+	for ty := range fields {
+		types = append(types, ty)
+	}
+	sort.Strings(types)
+
+	all := make([]interface{}, len(types))
+	for i, ty := range types {
+		all[i] = fields[ty]
+	}
+
+	return all
+}
+
+// Returns the names of the registered categories, sorted
+func Categories() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	categories := make([]string, 0, len(registry))
+	for category := range registry {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	return categories
+}
+
+// An error to describe a category that couldn't be registered
+type RegisterError struct {
+	Category,
+	Message string
+}
+
+func (e *RegisterError) Error() string {
+	return "can't register exam category '" + e.Category + "': " + e.Message
+}
@@ -0,0 +1,78 @@
+package exams
+
+import (
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	parsers := Parsers{
+		"registrytest.slow": func(config config.Exam) (Exam, error) { return &slowExam{}, nil },
+	}
+
+	assert.Nil(t, Register("registrytest", parsers.Get))
+	assert.Contains(t, Categories(), "registrytest")
+
+	parse, ok := GetParser("registrytest.slow")
+	assert.True(t, ok)
+
+	exam, err := parse(config.Exam{Type: "registrytest.slow"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test.slow", exam.Type())
+
+	_, ok = GetParser("registrytest.fast")
+	assert.False(t, ok)
+
+	_, ok = GetParser("unregistered.slow")
+	assert.False(t, ok)
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	assert.Nil(t, Register("registrydup", Parsers{}.Get))
+
+	var registerErr *RegisterError
+	assert.ErrorAs(t, Register("registrydup", Parsers{}.Get), &registerErr)
+	assert.ErrorAs(t, Register("", Parsers{}.Get), &registerErr)
+	assert.ErrorAs(t, Register("a.b", Parsers{}.Get), &registerErr)
+	assert.ErrorAs(t, Register("registrynil", nil), &registerErr)
+
+	assert.Panics(t, func() { MustRegister("registrydup", Parsers{}.Get) })
+}
+
+// An exam with specific fields, to test RegisterFields
+type fieldsExam struct{ slowExam }
+
+type fieldsExamFields struct {
+	Vars []string `yaml:"vars"`
+}
+
+func (e *fieldsExam) Type() string        { return "registryfields.exam" }
+func (e *fieldsExam) Fields() interface{} { return &fieldsExamFields{} }
+
+func TestRegisterFields(t *testing.T) {
+	RegisterFields(&fieldsExam{}, &slowExam{})
+
+	fields, ok := ExamFields("registryfields.exam")
+	assert.True(t, ok)
+	assert.Equal(t, &fieldsExamFields{}, fields)
+	assert.Contains(t, AllExamFields(), fields)
+
+	// Exams without specific fields aren't registered
+	_, ok = ExamFields("test.slow")
+	assert.False(t, ok)
+}
+
+func TestNewParsers(t *testing.T) {
+	parsers := NewParsers(&fieldsExam{}, &slowExam{})
+	assert.Len(t, parsers, 2)
+
+	_, ok := parsers.Get("registryfields.exam")
+	assert.True(t, ok)
+
+	// Only one of the exams with the same type could be parsed
+	assert.PanicsWithError(t, "can't register exam category 'registryfields': exam type 'registryfields.exam' is declared twice", func() {
+		NewParsers(&fieldsExam{}, &slowExam{}, &fieldsExam{})
+	})
+}
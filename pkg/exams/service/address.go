@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// The address of a service, written as `<protocol>:<port>` for local services or
+// `<protocol>://<host>:<port>` for remote ones. The protocol is either `tcp` or `udp`
+type Address struct {
+	raw     string
+	Network string
+	Host    string
+	Port    int
+	Local   bool
+}
+
+func ParseAddress(raw string) (Address, error) {
+	addr := Address{raw: raw}
+
+	network, rest, ok := strings.Cut(raw, ":")
+	if !ok {
+		return addr, fmt.Errorf("expected `<protocol>:<port>` or `<protocol>://<host>:<port>`")
+	}
+
+	if network != "tcp" && network != "udp" {
+		return addr, fmt.Errorf("unknown protocol %v, expected tcp or udp", network)
+	}
+	addr.Network = network
+
+	port := rest
+	if remote, ok := strings.CutPrefix(rest, "//"); ok {
+		host, p, err := net.SplitHostPort(remote)
+		if err != nil {
+			return addr, err
+		}
+
+		if host == "" {
+			return addr, fmt.Errorf("missing host")
+		}
+
+		addr.Host = host
+		port = p
+	} else {
+		addr.Host = "localhost"
+		addr.Local = true
+	}
+
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return addr, fmt.Errorf("invalid port %v, expected a number between 1 and 65535", port)
+	}
+	addr.Port = n
+
+	return addr, nil
+}
+
+func (a Address) String() string {
+	return a.raw
+}
+
+func (a Address) HostPort() string {
+	return net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
+}
+
+// Returned by Dial when a UDP service neither answers nor refuses the probe, so it can't be told if it's up
+var ErrNoAnswer = errors.New("no answer to the probe, the port may be open or filtered by a firewall")
+
+// Checks if a service can be reached by dialing its address
+// TCP services are reachable if a connection can be established. As UDP is connectionless, a datagram is sent
+// and the service is considered unreachable if the host actively refuses it (ICMP port unreachable)
+// If there's no answer at all, ErrNoAnswer is returned
+func Dial(ctx context.Context, addr Address, timeout time.Duration) error {
+	dialer := net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, addr.Network, addr.HostPort())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if addr.Network == "tcp" {
+		return nil
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write([]byte{0}); err != nil {
+		return err
+	}
+
+	_, err = conn.Read(make([]byte, 1))
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrNoAnswer
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a service is not running
+// This is the opposite of `service.is-up`
+//
+// type: service.is-down,
+// ports: []string,
+// dial-timeout: duration (default: 2s)
+type IsDown struct {
+	Ports
+}
+
+func (s *IsDown) Type() string {
+	return "service.is-down"
+}
+
+func (s *IsDown) Parse(conf config.Exam) (exams.Exam, error) {
+	return DefaultParse[*IsDown](conf, false, func(ports Ports) exams.Exam {
+		return &IsDown{ports}
+	})
+}
+
+func (s *IsDown) Examinate() exams.Report {
+	return s.ExaminateContext(context.Background())
+}
+
+func (s *IsDown) ExaminateContext(ctx context.Context) exams.Report {
+	return DefaultExaminate(s.Type(), s.Ports, func(addr Address) (int, string) {
+		if addr.Local {
+			if listening, _ := Listening(addr); listening {
+				return medik.ERROR, "should be down, but is listening"
+			}
+		}
+
+		err := Dial(ctx, addr, s.Timeout)
+
+		switch {
+		case errors.Is(err, ErrNoAnswer):
+			return medik.WARNING, "may be up: " + err.Error()
+		case err == nil:
+			return medik.ERROR, "should be down, but is up"
+		}
+
+		return medik.OK, "is down"
+	})
+}
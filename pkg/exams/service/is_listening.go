@@ -0,0 +1,41 @@
+package service
+
+import (
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a local port is being listened on
+// Instead of dialing, the socket tables of the system (/proc/net) are inspected
+//
+// type: service.is-listening,
+// ports: []string (local ports only, like `tcp:8080` or `udp:53`)
+type IsListening struct {
+	Ports
+}
+
+func (s *IsListening) Type() string {
+	return "service.is-listening"
+}
+
+func (s *IsListening) Parse(conf config.Exam) (exams.Exam, error) {
+	return DefaultParse[*IsListening](conf, true, func(ports Ports) exams.Exam {
+		return &IsListening{ports}
+	})
+}
+
+func (s *IsListening) Examinate() exams.Report {
+	return DefaultExaminate(s.Type(), s.Ports, func(addr Address) (int, string) {
+		listening, err := Listening(addr)
+		if err != nil {
+			return medik.ERROR, "couldn't check sockets: " + err.Error()
+		}
+
+		if !listening {
+			return medik.ERROR, "is not listening"
+		}
+
+		return medik.OK, "is listening"
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a local port is not being listened on
+// Instead of dialing, the socket tables of the system (/proc/net) are inspected
+//
+// type: service.is-not-listening,
+// ports: []string (local ports only, like `tcp:8080` or `udp:53`)
+type IsNotListening struct {
+	Ports
+}
+
+func (s *IsNotListening) Type() string {
+	return "service.is-not-listening"
+}
+
+func (s *IsNotListening) Parse(conf config.Exam) (exams.Exam, error) {
+	return DefaultParse[*IsNotListening](conf, true, func(ports Ports) exams.Exam {
+		return &IsNotListening{ports}
+	})
+}
+
+func (s *IsNotListening) Examinate() exams.Report {
+	return DefaultExaminate(s.Type(), s.Ports, func(addr Address) (int, string) {
+		listening, err := Listening(addr)
+		if err != nil {
+			return medik.ERROR, "couldn't check sockets: " + err.Error()
+		}
+
+		if listening {
+			return medik.ERROR, "should not be listening, but is"
+		}
+
+		return medik.OK, "is not listening"
+	})
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a service can't be reached by dialing its address
+//
+// type: service.is-not-reachable,
+// ports: []string,
+// dial-timeout: duration (default: 2s)
+type IsNotReachable struct {
+	Ports
+}
+
+func (s *IsNotReachable) Type() string {
+	return "service.is-not-reachable"
+}
+
+func (s *IsNotReachable) Parse(conf config.Exam) (exams.Exam, error) {
+	return DefaultParse[*IsNotReachable](conf, false, func(ports Ports) exams.Exam {
+		return &IsNotReachable{ports}
+	})
+}
+
+func (s *IsNotReachable) Examinate() exams.Report {
+	return s.ExaminateContext(context.Background())
+}
+
+func (s *IsNotReachable) ExaminateContext(ctx context.Context) exams.Report {
+	return DefaultExaminate(s.Type(), s.Ports, func(addr Address) (int, string) {
+		err := Dial(ctx, addr, s.Timeout)
+
+		switch {
+		case errors.Is(err, ErrNoAnswer):
+			return medik.WARNING, "may be reachable: " + err.Error()
+		case err == nil:
+			return medik.ERROR, "should not be reachable, but is"
+		}
+
+		return medik.OK, "is not reachable"
+	})
+}
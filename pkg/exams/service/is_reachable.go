@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a service can be reached by dialing its address
+//
+// type: service.is-reachable,
+// ports: []string,
+// dial-timeout: duration (default: 2s)
+type IsReachable struct {
+	Ports
+}
+
+func (s *IsReachable) Type() string {
+	return "service.is-reachable"
+}
+
+func (s *IsReachable) Parse(conf config.Exam) (exams.Exam, error) {
+	return DefaultParse[*IsReachable](conf, false, func(ports Ports) exams.Exam {
+		return &IsReachable{ports}
+	})
+}
+
+func (s *IsReachable) Examinate() exams.Report {
+	return s.ExaminateContext(context.Background())
+}
+
+func (s *IsReachable) ExaminateContext(ctx context.Context) exams.Report {
+	return DefaultExaminate(s.Type(), s.Ports, func(addr Address) (int, string) {
+		err := Dial(ctx, addr, s.Timeout)
+
+		switch {
+		case errors.Is(err, ErrNoAnswer):
+			return medik.WARNING, "may not be reachable: " + err.Error()
+		case err != nil:
+			return medik.ERROR, "is not reachable: " + err.Error()
+		}
+
+		return medik.OK, "is reachable"
+	})
+}
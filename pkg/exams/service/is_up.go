@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Check if a service is running
+// Local services are up if their port is being listened on or they can be dialed, remote ones if they can be dialed
+//
+// type: service.is-up,
+// ports: []string,
+// dial-timeout: duration (default: 2s)
+type IsUp struct {
+	Ports
+}
+
+func (s *IsUp) Type() string {
+	return "service.is-up"
+}
+
+func (s *IsUp) Parse(conf config.Exam) (exams.Exam, error) {
+	return DefaultParse[*IsUp](conf, false, func(ports Ports) exams.Exam {
+		return &IsUp{ports}
+	})
+}
+
+func (s *IsUp) Examinate() exams.Report {
+	return s.ExaminateContext(context.Background())
+}
+
+func (s *IsUp) ExaminateContext(ctx context.Context) exams.Report {
+	return DefaultExaminate(s.Type(), s.Ports, func(addr Address) (int, string) {
+		if addr.Local {
+			if listening, _ := Listening(addr); listening {
+				return medik.OK, "is listening"
+			}
+		}
+
+		err := Dial(ctx, addr, s.Timeout)
+
+		switch {
+		case errors.Is(err, ErrNoAnswer):
+			return medik.WARNING, "may be down: " + err.Error()
+		case err != nil:
+			return medik.ERROR, "is down: " + err.Error()
+		}
+
+		return medik.OK, "is up"
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The state of a listening TCP socket in /proc/net/tcp
+const tcpListen = "0A"
+
+// Where sockets are listed on Linux, by network
+var procNetFiles = map[string][]string{
+	"tcp": {"/proc/net/tcp", "/proc/net/tcp6"},
+	"udp": {"/proc/net/udp", "/proc/net/udp6"},
+}
+
+// Checks if a local port is being listened on by inspecting /proc/net
+// TCP sockets must be in the LISTEN state, while any bound UDP socket counts as listening
+// Returns an error if the socket tables can't be read (e.g. not running on Linux)
+func Listening(addr Address) (bool, error) {
+	found := false
+
+	for _, path := range procNetFiles[addr.Network] {
+		listening, err := listensOn(path, addr.Network, addr.Port)
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		found = true
+		if listening {
+			return true, nil
+		}
+	}
+
+	if !found {
+		return false, &os.PathError{Op: "open", Path: procNetFiles[addr.Network][0], Err: os.ErrNotExist}
+	}
+
+	return false, nil
+}
+
+func listensOn(path, network string, port int) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Skip the header
+	scanner.Scan()
+
+	for scanner.Scan() {
+		// sl local_address rem_address st ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+
+		_, hexPort, ok := strings.Cut(fields[1], ":")
+		if !ok {
+			continue
+		}
+
+		p, err := strconv.ParseUint(hexPort, 16, 16)
+		if err != nil || int(p) != port {
+			continue
+		}
+
+		if network == "udp" || fields[3] == tcpListen {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
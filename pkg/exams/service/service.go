@@ -0,0 +1,156 @@
+package service
+
+import (
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/format"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// The timeout used to dial a service when the exam doesn't set `dial-timeout`
+const DefaultDialTimeout = 2 * time.Second
+
+// Function to get a parser for a given type `service.*`
+// Returns the parser and a boolean indicating if the parser was found
+func GetParser(ty string) (exams.Parser, bool) {
+	return parsers.Get(ty)
+}
+
+// Zero values of the exams of the category
+var all = []exams.Exam{
+	&IsUp{},
+	&IsDown{},
+	&IsReachable{},
+	&IsNotReachable{},
+	&IsListening{},
+	&IsNotListening{},
+}
+
+var parsers = exams.NewParsers(all...)
+
+func init() {
+	exams.MustRegister("service", GetParser)
+	exams.RegisterFields(all...)
+}
+
+// A report that is returned from a `service.*` exam
+type ServiceReport struct {
+	Type     string
+	Lvl      int
+	Statuses []ServiceStatus
+}
+
+// A status from a part of the execution of a `service.*` exam
+type ServiceStatus struct {
+	Lvl     int
+	Port    string
+	Message string
+}
+
+func (r *ServiceReport) Level() int {
+	return r.Lvl
+}
+
+func (r *ServiceReport) Format(verbosity int, noColor bool) (int, string, string) {
+	statuses := ""
+
+	for _, status := range r.Statuses {
+		if status.Lvl >= verbosity {
+			statuses += format.ReportStatus(status.Port, status.Message, status.Lvl, noColor) + "\n"
+		}
+	}
+
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), statuses
+}
+
+func (r *ServiceReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Statuses))
+
+	for i, status := range r.Statuses {
+		statuses[i] = exams.Status{Key: status.Port, Message: status.Message, Level: status.Lvl}
+	}
+
+	return exams.ReportData{Exam: r.Type, Level: r.Lvl, Statuses: statuses}
+}
+
+// The common fields of every `service.*` exam
+type Ports struct {
+	Addresses []Address
+	Timeout   time.Duration
+	Level     int
+}
+
+// The fields of every `service.*` exam, as written in the config
+type PortsFields struct {
+	Ports       []string `yaml:"ports"`
+	DialTimeout string   `yaml:"dial-timeout"`
+}
+
+func (p Ports) Fields() interface{} {
+	return &PortsFields{}
+}
+
+// Default implementation for Examinate method of exams.Exam. Every address is validated using the `validate`
+// function, which returns the level of the address and a message describing it: medik.OK if it's in the
+// expected state, medik.WARNING if that can't be told and medik.ERROR if it isn't. Levels are capped by the
+// level of the exam
+func DefaultExaminate(exam string, ports Ports, validate func(addr Address) (int, string)) *ServiceReport {
+	statuses := []ServiceStatus{}
+	level := 0
+
+	for _, addr := range ports.Addresses {
+		lvl, message := validate(addr)
+		lvl = min(lvl, ports.Level)
+
+		statuses = append(statuses, ServiceStatus{Lvl: lvl, Port: addr.String(), Message: message})
+		level = max(level, lvl)
+	}
+
+	return &ServiceReport{Type: exam, Lvl: level, Statuses: statuses}
+}
+
+// Default implementation for Parse method of exams.Exam. It parses the `ports` and `dial-timeout` fields
+// If `localOnly` is set, only local ports (like `tcp:8080`) are accepted
+func DefaultParse[E exams.Exam](config config.Exam, localOnly bool, f func(ports Ports) exams.Exam) (exams.Exam, error) {
+	var e E
+	ty := e.Type()
+	if config.Type != ty {
+		return nil, &exams.WrongExamParserError{Source: config.Type, Using: ty}
+	}
+
+	fields := &PortsFields{}
+	if err := config.Decode(fields); err != nil {
+		return nil, err
+	}
+
+	if len(fields.Ports) == 0 {
+		return nil, &exams.MissingFieldError{Field: "ports", Exam: ty}
+	}
+
+	ports := Ports{Timeout: DefaultDialTimeout, Level: medik.LogLevelFromStr(config.Level)}
+
+	if fields.DialTimeout != "" {
+		timeout, err := time.ParseDuration(fields.DialTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, &exams.FieldValueError{Field: "dial-timeout", Exam: ty, Value: fields.DialTimeout, Message: "expected a positive duration like 2s"}
+		}
+		ports.Timeout = timeout
+	}
+
+	for _, raw := range fields.Ports {
+		addr, err := ParseAddress(raw)
+		if err != nil {
+			return nil, &exams.FieldValueError{Field: "ports", Exam: ty, Value: raw, Message: err.Error()}
+		}
+
+		if localOnly && !addr.Local {
+			return nil, &exams.FieldValueError{Field: "ports", Exam: ty, Value: raw, Message: "only local ports like `tcp:8080` can be checked"}
+		}
+
+		ports.Addresses = append(ports.Addresses, addr)
+	}
+
+	return f(ports), nil
+}
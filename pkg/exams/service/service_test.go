@@ -0,0 +1,151 @@
+package service
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a port that was free at the moment of the call
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return port
+}
+
+func parseExam(t *testing.T, ty string, ports ...string) *ServiceReport {
+	parse, ok := GetParser(ty)
+	assert.True(t, ok)
+
+	exam, err := parse(config.NewExam(ty, map[string]interface{}{"ports": ports, "dial-timeout": "500ms"}))
+	assert.Nil(t, err)
+
+	return exam.Examinate().(*ServiceReport)
+}
+
+func TestParseAddress(t *testing.T) {
+	addr, err := ParseAddress("tcp:8081")
+	assert.Nil(t, err)
+	assert.Equal(t, "tcp", addr.Network)
+	assert.Equal(t, 8081, addr.Port)
+	assert.True(t, addr.Local)
+	assert.Equal(t, "localhost:8081", addr.HostPort())
+
+	addr, err = ParseAddress("udp://[::1]:53")
+	assert.Nil(t, err)
+	assert.Equal(t, "udp", addr.Network)
+	assert.Equal(t, "::1", addr.Host)
+	assert.False(t, addr.Local)
+	assert.Equal(t, "udp://[::1]:53", addr.String())
+
+	for _, raw := range []string{"8081", "http:80", "tcp:http", "tcp:0", "tcp:70000", "tcp://:80", "tcp://host"} {
+		_, err = ParseAddress(raw)
+		assert.NotNil(t, err, raw)
+	}
+}
+
+func TestServiceParse(t *testing.T) {
+	exam := &IsListening{}
+
+	// Test invalid type
+	_, err := exam.Parse(config.Exam{Type: "invalid"})
+	assert.NotNil(t, err)
+
+	// Test ports not set
+	_, err = exam.Parse(config.Exam{Type: "service.is-listening"})
+	assert.NotNil(t, err)
+
+	// Test remote ports are not accepted for listening
+	_, err = exam.Parse(config.NewExam("service.is-listening", map[string]interface{}{"ports": []string{"tcp://example.com:80"}}))
+	assert.NotNil(t, err)
+
+	// Test invalid timeout
+	_, err = (&IsReachable{}).Parse(config.NewExam("service.is-reachable", map[string]interface{}{"ports": []string{"tcp:80"}, "dial-timeout": "never"}))
+	assert.NotNil(t, err)
+
+	// Test valid config
+	parsed, err := exam.Parse(config.NewExam("service.is-listening", map[string]interface{}{"ports": []string{"tcp:8080"}}))
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultDialTimeout, parsed.(*IsListening).Timeout)
+	assert.Equal(t, medik.ERROR, parsed.(*IsListening).Level)
+}
+
+func TestServiceTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	remote := "tcp://127.0.0.1:" + port
+	closed := "tcp://127.0.0.1:" + freePort(t)
+
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-reachable", remote).Level())
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-up", remote).Level())
+	assert.Equal(t, medik.ERROR, parseExam(t, "service.is-down", remote).Level())
+	assert.Equal(t, medik.ERROR, parseExam(t, "service.is-not-reachable", remote).Level())
+
+	assert.Equal(t, medik.ERROR, parseExam(t, "service.is-reachable", closed).Level())
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-down", closed).Level())
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-not-reachable", closed).Level())
+}
+
+func TestServiceListening(t *testing.T) {
+	if _, err := os.Stat("/proc/net/tcp"); err != nil {
+		t.Skip("/proc/net/tcp is not available")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-listening", "tcp:"+port).Level())
+	assert.Equal(t, medik.ERROR, parseExam(t, "service.is-not-listening", "tcp:"+port).Level())
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-up", "tcp:"+port).Level())
+
+	closed := freePort(t)
+	assert.Equal(t, medik.ERROR, parseExam(t, "service.is-listening", "tcp:"+closed).Level())
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-not-listening", "tcp:"+closed).Level())
+}
+
+func TestServiceUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+
+	// A service that doesn't answer may be up or filtered, so it's neither up nor down
+	for _, ty := range []string{"service.is-reachable", "service.is-not-reachable", "service.is-down"} {
+		report := parseExam(t, ty, "udp://127.0.0.1:"+port)
+		assert.Equal(t, medik.WARNING, report.Level(), ty)
+		assert.Contains(t, report.Statuses[0].Message, ErrNoAnswer.Error(), ty)
+	}
+
+	if _, err := os.Stat("/proc/net/udp"); err == nil {
+		assert.Equal(t, medik.OK, parseExam(t, "service.is-listening", "udp:"+port).Level())
+	}
+
+	// A service that answers is up
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			conn.WriteTo(buf[:n], from)
+		}
+	}()
+
+	assert.Equal(t, medik.OK, parseExam(t, "service.is-reachable", "udp://127.0.0.1:"+port).Level())
+	assert.Equal(t, medik.ERROR, parseExam(t, "service.is-down", "udp://127.0.0.1:"+port).Level())
+}
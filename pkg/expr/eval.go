@@ -0,0 +1,398 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// An error found while evaluating an expression. Source is the sub-expression that failed
+// Redacted is the same as Message, without the values of the operands, which may come from secrets
+type EvalError struct {
+	Source   string
+	Message  string
+	Redacted string
+}
+
+func (e *EvalError) Error() string {
+	return e.Source + ": " + e.Message
+}
+
+// Same as Error, without the values of the operands
+func (e *EvalError) RedactedError() string {
+	return e.Source + ": " + e.Redacted
+}
+
+// An error of a function about the value of one of its arguments
+// Redacted is the same as the message, without the value
+type ValueError struct {
+	Message  string
+	Redacted string
+}
+
+func (e *ValueError) Error() string {
+	return e.Message
+}
+
+// Returns the message of an error returned by a function, without the values of its arguments
+// Errors other than ValueError may include anything, so only a generic message is returned for them
+func redacted(err error) string {
+	var valueErr *ValueError
+	if errors.As(err, &valueErr) {
+		return valueErr.Redacted
+	}
+
+	return "the call failed"
+}
+
+// A function that can be called in an expression
+// Values are nil (null), string, float64, bool or map[string]any (records)
+// Errors about the values of the arguments should be a *ValueError, so they can be reported without them
+type Function struct {
+	Args int
+	Call func(args []any) (any, error)
+}
+
+// The functions that can be called in an expression
+var Functions = map[string]Function{
+	// If the value is set
+	"set": {1, func(args []any) (any, error) {
+		return args[0] != nil, nil
+	}},
+	// If the value is not set or blank
+	"empty": {1, func(args []any) (any, error) {
+		s, ok := args[0].(string)
+		return args[0] == nil || (ok && strings.TrimSpace(s) == ""), nil
+	}},
+	"int": {1, func(args []any) (any, error) {
+		if n, ok := args[0].(float64); ok && n == float64(int64(n)) {
+			return n, nil
+		}
+
+		s, err := str(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, &ValueError{fmt.Sprintf("'%v' is not an integer", s), "the value is not an integer"}
+		}
+
+		return float64(n), nil
+	}},
+	"float": {1, func(args []any) (any, error) {
+		if n, ok := args[0].(float64); ok {
+			return n, nil
+		}
+
+		s, err := str(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, &ValueError{fmt.Sprintf("'%v' is not a number", s), "the value is not a number"}
+		}
+
+		return n, nil
+	}},
+	"bool": {1, func(args []any) (any, error) {
+		if b, ok := args[0].(bool); ok {
+			return b, nil
+		}
+
+		s, err := str(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, &ValueError{fmt.Sprintf("'%v' is not a boolean", s), "the value is not a boolean"}
+		}
+
+		return b, nil
+	}},
+	// Parses an absolute URL into a record with the `scheme`, `host` (without the port), `port`, `path` and `query` fields
+	"url": {1, func(args []any) (any, error) {
+		s, err := str(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := url.Parse(strings.TrimSpace(s))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, &ValueError{fmt.Sprintf("'%v' is not an absolute URL", s), "the value is not an absolute URL"}
+		}
+
+		return map[string]any{"scheme": u.Scheme, "host": u.Hostname(), "port": u.Port(), "path": u.Path, "query": u.RawQuery}, nil
+	}},
+	"len": {1, func(args []any) (any, error) {
+		s, err := str(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return float64(len([]rune(s))), nil
+	}},
+	"lower": {1, func(args []any) (any, error) {
+		s, err := str(args[0])
+		return strings.ToLower(s), err
+	}},
+	// If the value matches a regular expression
+	"matches": {2, func(args []any) (any, error) {
+		s, err := str(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		pattern, ok := args[1].(string)
+		if !ok {
+			return nil, &ValueError{"the regex should be a string, got " + Format(args[1]), "the regex should be a string, got " + Kind(args[1])}
+		}
+
+		// The pattern may come from a variable too
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, &ValueError{err.Error(), "the regex is not valid"}
+		}
+
+		return regex.MatchString(s), nil
+	}},
+}
+
+// Returns the string of a value, or an error if it's not set or not a string
+func str(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", &ValueError{"the value is not set", "the value is not set"}
+	case string:
+		return v, nil
+	}
+
+	return "", &ValueError{"expected a string, got " + Format(value), "expected a string, got " + Kind(value)}
+}
+
+// Formats a value as it would be written in an expression
+func Format(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + v + "'"
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	return "a record"
+}
+
+// Describes the type of a value, like `a string`. Unlike Format, it never shows the value itself
+func Kind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+
+	return "a record"
+}
+
+// Evaluates the expression, whose result must be a boolean. Variables are looked up with `lookup`
+// If it's false, it also returns the sub-expression that made it false: the first false operand of
+// `&&` operators, or the whole `||` or comparison that was false
+func (e *Expr) Check(lookup func(name string) (string, bool)) (bool, Node, error) {
+	return check(e.root, lookup)
+}
+
+func check(node Node, lookup func(name string) (string, bool)) (bool, Node, error) {
+	if n, ok := node.(*Binary); ok && n.Op == "&&" {
+		for _, operand := range []Node{n.X, n.Y} {
+			ok, failed, err := check(operand, lookup)
+			if err != nil || !ok {
+				return ok, failed, err
+			}
+		}
+
+		return true, nil, nil
+	}
+
+	value, err := Eval(node, lookup)
+	if err != nil {
+		return false, nil, err
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return false, nil, &EvalError{Source: node.Source(), Message: "expected a boolean, got " + Format(value), Redacted: "expected a boolean, got " + Kind(value)}
+	}
+
+	if !b {
+		return false, node, nil
+	}
+
+	return true, nil, nil
+}
+
+// Evaluates a node. Variables are looked up with `lookup`, and the ones not set are null
+func Eval(node Node, lookup func(name string) (string, bool)) (any, error) {
+	switch n := node.(type) {
+	case *Literal:
+		return n.Value, nil
+	case *Var:
+		if value, ok := lookup(n.Name); ok {
+			return value, nil
+		}
+
+		return nil, nil
+	case *Call:
+		args := make([]any, len(n.Args))
+
+		for i, arg := range n.Args {
+			value, err := Eval(arg, lookup)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = value
+		}
+
+		value, err := Functions[n.Name].Call(args)
+		if err != nil {
+			return nil, &EvalError{Source: n.src, Message: err.Error(), Redacted: redacted(err)}
+		}
+
+		return value, nil
+	case *Field:
+		x, err := Eval(n.X, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		record, ok := x.(map[string]any)
+		if !ok {
+			return nil, &EvalError{Source: n.src, Message: "expected a record, got " + Format(x), Redacted: "expected a record, got " + Kind(x)}
+		}
+
+		value, ok := record[n.Name]
+		if !ok {
+			return nil, &EvalError{Source: n.src, Message: "unknown field '" + n.Name + "'", Redacted: "unknown field '" + n.Name + "'"}
+		}
+
+		return value, nil
+	case *Unary:
+		x, err := evalBool(n.X, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		return !x, nil
+	case *Binary:
+		return evalBinary(n, lookup)
+	}
+
+	return nil, fmt.Errorf("unknown node %T", node)
+}
+
+func evalBool(node Node, lookup func(name string) (string, bool)) (bool, error) {
+	value, err := Eval(node, lookup)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return false, &EvalError{Source: node.Source(), Message: "expected a boolean, got " + Format(value), Redacted: "expected a boolean, got " + Kind(value)}
+	}
+
+	return b, nil
+}
+
+func evalBinary(n *Binary, lookup func(name string) (string, bool)) (any, error) {
+	// Logical operators short-circuit, so `set(X) && int(X) > 0` doesn't fail when X is not set
+	if n.Op == "&&" || n.Op == "||" {
+		x, err := evalBool(n.X, lookup)
+		if err != nil || x == (n.Op == "||") {
+			return x, err
+		}
+
+		return evalBool(n.Y, lookup)
+	}
+
+	x, err := Eval(n.X, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := Eval(n.Y, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anything can be compared with null, which is only equal to itself
+	if x == nil || y == nil {
+		switch n.Op {
+		case "==":
+			return x == y, nil
+		case "!=":
+			return x != y, nil
+		}
+	}
+
+	switch x := x.(type) {
+	case string:
+		if y, ok := y.(string); ok {
+			return compare(n.Op, strings.Compare(x, y)), nil
+		}
+	case float64:
+		if y, ok := y.(float64); ok {
+			switch {
+			case x < y:
+				return compare(n.Op, -1), nil
+			case x > y:
+				return compare(n.Op, 1), nil
+			}
+
+			return compare(n.Op, 0), nil
+		}
+	case bool:
+		if y, ok := y.(bool); ok && (n.Op == "==" || n.Op == "!=") {
+			return (x == y) == (n.Op == "=="), nil
+		}
+	}
+
+	return nil, &EvalError{
+		Source:   n.src,
+		Message:  fmt.Sprintf("can't compare %v and %v with %v", Format(x), Format(y), n.Op),
+		Redacted: fmt.Sprintf("can't compare %v and %v with %v", Kind(x), Kind(y), n.Op),
+	}
+}
+
+// Returns the result of a comparison operator, given the sign of the difference of its operands
+func compare(op string, sign int) bool {
+	switch op {
+	case "==":
+		return sign == 0
+	case "!=":
+		return sign != 0
+	case "<":
+		return sign < 0
+	case "<=":
+		return sign <= 0
+	case ">":
+		return sign > 0
+	}
+
+	return sign >= 0
+}
@@ -0,0 +1,486 @@
+// Package expr implements a small language of boolean expressions over environment variables, like
+// `bool(DB_SSL) && set(DB_CERT)` or `int(MIN_POOL) <= int(MAX_POOL)`
+package expr
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A syntax error in an expression. Column starts at 1
+type Error struct {
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// A parsed expression, made of:
+//   - Variables, like `PORT`, whose value is a string or null if they're not set
+//   - String ('...' or "..."), number, `true`, `false` and `null` literals
+//   - Calls to the functions listed in Functions, like `int(PORT)`
+//   - Fields of records, like `url(PUBLIC_URL).host`
+//   - Comparisons with `==`, `!=`, `<`, `<=`, `>` and `>=`
+//   - `!`, `&&` and `||`, with the usual precedence, and parentheses
+type Expr struct {
+	src  string
+	root Node
+}
+
+// A node of an expression
+type Node interface {
+	// The source of the node, as written in the expression
+	Source() string
+}
+
+// Nodes of an expression. Each keeps its source
+type (
+	Literal struct {
+		src   string
+		Value any
+	}
+
+	Var struct {
+		src  string
+		Name string
+	}
+
+	Call struct {
+		src  string
+		Name string
+		Args []Node
+	}
+
+	Field struct {
+		src  string
+		X    Node
+		Name string
+	}
+
+	Unary struct {
+		src string
+		Op  string
+		X   Node
+	}
+
+	Binary struct {
+		src string
+		Op  string
+		X,
+		Y Node
+	}
+)
+
+func (n *Literal) Source() string { return n.src }
+func (n *Var) Source() string     { return n.src }
+func (n *Call) Source() string    { return n.src }
+func (n *Field) Source() string   { return n.src }
+func (n *Unary) Source() string   { return n.src }
+func (n *Binary) Source() string  { return n.src }
+
+// Parses an expression
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src}
+	p.next()
+
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf(p.tok.pos, "unexpected '%v'", p.tok.text)
+	}
+
+	return &Expr{src: src, root: root}, nil
+}
+
+// The source of the expression
+func (e *Expr) String() string {
+	return e.src
+}
+
+// The root node of the expression
+func (e *Expr) Root() Node {
+	return e.root
+}
+
+// Returns the names of the variables used by `node`, in the order they first appear
+func Vars(node Node) []string {
+	names := []string{}
+	seen := map[string]bool{}
+
+	var walk func(Node)
+	walk = func(node Node) {
+		switch n := node.(type) {
+		case *Var:
+			if !seen[n.Name] {
+				seen[n.Name] = true
+				names = append(names, n.Name)
+			}
+		case *Call:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *Field:
+			walk(n.X)
+		case *Unary:
+			walk(n.X)
+		case *Binary:
+			walk(n.X)
+			walk(n.Y)
+		}
+	}
+	walk(node)
+
+	return names
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// The value of string literals, without quotes and escapes
+	value string
+	pos   int
+	end   int
+}
+
+// The state of the parsing of an expression
+type parser struct {
+	src string
+	pos int
+	tok token
+	// The end of the last token consumed
+	last int
+	err  error
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &Error{Column: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", ",", "."}
+
+// Reads the next token. Lexical errors are kept in p.err and reported by the parser
+func (p *parser) next() {
+	p.last = p.tok.end
+
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+	p.tok = token{kind: tokEOF, pos: start, end: start}
+
+	if p.pos >= len(p.src) {
+		return
+	}
+
+	c := p.src[p.pos]
+
+	switch {
+	case isIdentStart(c):
+		for p.pos < len(p.src) && isIdent(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok.kind = tokIdent
+	case isDigit(c):
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		p.tok.kind = tokNumber
+	case c == '\'' || c == '"':
+		value, ok := p.quoted(c)
+		if !ok {
+			p.err = p.errorf(start, "unterminated string")
+			p.pos = len(p.src)
+		}
+		p.tok.kind = tokString
+		p.tok.value = value
+	default:
+		for _, op := range operators {
+			if strings.HasPrefix(p.src[p.pos:], op) {
+				p.pos += len(op)
+				p.tok.kind = tokOp
+				break
+			}
+		}
+
+		if p.tok.kind != tokOp {
+			p.err = p.errorf(start, "unexpected character '%c'", c)
+			p.pos = len(p.src)
+		}
+	}
+
+	p.tok.text = p.src[start:p.pos]
+	p.tok.end = p.pos
+}
+
+// Reads a string literal quoted by `quote`, where `\` escapes the next character
+func (p *parser) quoted(quote byte) (string, bool) {
+	var value strings.Builder
+	p.pos++
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+
+		switch {
+		case c == quote:
+			return value.String(), true
+		case c == '\\' && p.pos < len(p.src):
+			value.WriteByte(p.src[p.pos])
+			p.pos++
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return "", false
+}
+
+// Consumes the current token if it's the operator `op`
+func (p *parser) accept(op string) bool {
+	if p.tok.kind == tokOp && p.tok.text == op {
+		p.next()
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	if !p.accept(op) {
+		return p.unexpected("'" + op + "'")
+	}
+
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	if p.tok.kind == tokEOF {
+		return p.errorf(p.tok.pos, "expected %v, found the end of the expression", expected)
+	}
+
+	return p.errorf(p.tok.pos, "expected %v, found '%v'", expected, p.tok.text)
+}
+
+// The source between `start` and the end of the last token consumed
+func (p *parser) source(start int) string {
+	return p.src[start:p.last]
+}
+
+func (p *parser) binary(ops []string, operand func() (Node, error)) (Node, error) {
+	start := p.tok.pos
+
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokOp {
+		op := p.tok.text
+		if !slices.Contains(ops, op) {
+			break
+		}
+		p.next()
+
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		x = &Binary{src: p.source(start), Op: op, X: x, Y: y}
+	}
+
+	return x, nil
+}
+
+func (p *parser) or() (Node, error) {
+	return p.binary([]string{"||"}, p.and)
+}
+
+func (p *parser) and() (Node, error) {
+	return p.binary([]string{"&&"}, p.comparison)
+}
+
+var comparisons = []string{"==", "!=", "<", "<=", ">", ">="}
+
+func (p *parser) comparison() (Node, error) {
+	start := p.tok.pos
+
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokOp && slices.Contains(comparisons, p.tok.text) {
+		op := p.tok.text
+		p.next()
+
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Binary{src: p.source(start), Op: op, X: x, Y: y}, nil
+	}
+
+	return x, nil
+}
+
+func (p *parser) unary() (Node, error) {
+	start := p.tok.pos
+
+	if p.accept("!") {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Unary{src: p.source(start), Op: "!", X: x}, nil
+	}
+
+	return p.postfix()
+}
+
+func (p *parser) postfix() (Node, error) {
+	start := p.tok.pos
+
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(".") {
+		if p.tok.kind != tokIdent {
+			return nil, p.unexpected("a field name")
+		}
+
+		name := p.tok.text
+		p.next()
+
+		x = &Field{src: p.source(start), X: x, Name: name}
+	}
+
+	return x, nil
+}
+
+func (p *parser) primary() (Node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	tok := p.tok
+
+	switch {
+	case tok.kind == tokNumber:
+		p.next()
+
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf(tok.pos, "invalid number '%v'", tok.text)
+		}
+
+		return &Literal{src: tok.text, Value: value}, nil
+	case tok.kind == tokString:
+		p.next()
+		return &Literal{src: tok.text, Value: tok.value}, nil
+	case tok.kind == tokIdent:
+		p.next()
+
+		switch tok.text {
+		case "true", "false":
+			return &Literal{src: tok.text, Value: tok.text == "true"}, nil
+		case "null":
+			return &Literal{src: tok.text, Value: nil}, nil
+		}
+
+		if !p.accept("(") {
+			return &Var{src: tok.text, Name: tok.text}, nil
+		}
+
+		return p.call(tok)
+	case p.accept("("):
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		return x, p.expect(")")
+	}
+
+	return nil, p.unexpected("a value")
+}
+
+// Parses the arguments of a call to the function named by `name`, after its opening parenthesis
+func (p *parser) call(name token) (Node, error) {
+	function, ok := Functions[name.text]
+	if !ok {
+		return nil, p.errorf(name.pos, "unknown function '%v'", name.text)
+	}
+
+	args := []Node{}
+
+	if !p.accept(")") {
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.accept(")") {
+				break
+			}
+
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(args) != function.Args {
+		return nil, p.errorf(name.pos, "%v expects %v arguments, got %v", name.text, function.Args, len(args))
+	}
+
+	return &Call{src: p.source(name.pos), Name: name.text, Args: args}, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,128 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var env = map[string]string{
+	"DB_SSL":     "true",
+	"DB_CERT":    "/etc/cert.pem",
+	"MIN_POOL":   "20",
+	"MAX_POOL":   "10",
+	"PUBLIC_URL": "https://example.com:8443/app",
+	"HOST":       "example.com",
+	"NAME":       "Medik",
+	"PORT":       "http",
+}
+
+func lookup(name string) (string, bool) {
+	value, ok := env[name]
+	return value, ok
+}
+
+func TestCheck(t *testing.T) {
+	lines := []struct {
+		Expr   string
+		Ok     bool
+		Failed string
+	}{
+		{"bool(DB_SSL) && set(DB_CERT)", true, ""},
+		{"!bool(DB_SSL) || set(DB_KEY)", false, "!bool(DB_SSL) || set(DB_KEY)"},
+		{"set(DB_CERT) && int(MIN_POOL) <= int(MAX_POOL)", false, "int(MIN_POOL) <= int(MAX_POOL)"},
+		{"url(PUBLIC_URL).host == HOST && url(PUBLIC_URL).port == '8443'", true, ""},
+		{"(set(NAME) && lower(NAME) == 'medik') && (len(NAME) > 5)", false, "len(NAME) > 5"},
+		{"MISSING == null && !empty(NAME)", true, ""},
+		{"set(MISSING) && int(MISSING) > 0", false, "set(MISSING)"},
+		{"matches(HOST, \"\\\\.com$\")", true, ""},
+		{"float(MIN_POOL) >= 19.5 && url(PUBLIC_URL).scheme != \"http\"", true, ""},
+	}
+
+	for _, l := range lines {
+		e, err := Parse(l.Expr)
+		if err != nil {
+			t.Errorf("Parse() failed: %q :: %v", l.Expr, err)
+			continue
+		}
+
+		ok, failed, err := e.Check(lookup)
+		if err != nil || ok != l.Ok || (failed == nil) != (l.Failed == "") || (failed != nil && failed.Source() != l.Failed) {
+			t.Errorf("Check() failed: %q :: %v, %v, %v", l.Expr, ok, failed, err)
+		}
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	lines := []struct {
+		Expr     string
+		Source   string
+		Message  string
+		Redacted string
+	}{
+		{"int(PORT) > 0", "int(PORT)", "'http' is not an integer", "the value is not an integer"},
+		{"int(lower(PORT)) > 0", "int(lower(PORT))", "'http' is not an integer", "the value is not an integer"},
+		{"int(MISSING) > 0", "int(MISSING)", "the value is not set", "the value is not set"},
+		{"url(HOST).host == HOST", "url(HOST)", "'example.com' is not an absolute URL", "the value is not an absolute URL"},
+		{"url(PUBLIC_URL).user == ''", "url(PUBLIC_URL).user", "unknown field 'user'", "unknown field 'user'"},
+		{"int(MIN_POOL) == MIN_POOL", "int(MIN_POOL) == MIN_POOL", "can't compare 20 and '20' with ==", "can't compare a number and a string with =="},
+		{"NAME", "NAME", "expected a boolean, got 'Medik'", "expected a boolean, got a string"},
+		{"set(NAME) && NAME", "NAME", "expected a boolean, got 'Medik'", "expected a boolean, got a string"},
+		{"matches(HOST, '(')", "matches(HOST, '(')", "error parsing regexp: missing closing ): `(`", "the regex is not valid"},
+		{"matches(HOST, lower(NAME) == 'x')", "matches(HOST, lower(NAME) == 'x')", "the regex should be a string, got false", "the regex should be a string, got a boolean"},
+	}
+
+	for _, l := range lines {
+		e, err := Parse(l.Expr)
+		if err != nil {
+			t.Errorf("Parse() failed: %q :: %v", l.Expr, err)
+			continue
+		}
+
+		_, _, err = e.Check(lookup)
+
+		var evalErr *EvalError
+		if !errors.As(err, &evalErr) || evalErr.Source != l.Source || evalErr.Message != l.Message || evalErr.Redacted != l.Redacted {
+			t.Errorf("Check() failed: %q :: %v", l.Expr, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	lines := []struct {
+		Expr    string
+		Column  int
+		Message string
+	}{
+		{"", 1, "expected a value, found the end of the expression"},
+		{"set(A", 6, "expected ',', found the end of the expression"},
+		{"A == 'b", 6, "unterminated string"},
+		{"A = B", 3, "unexpected character '='"},
+		{"nope(A)", 1, "unknown function 'nope'"},
+		{"int(A, B)", 1, "int expects 1 arguments, got 2"},
+		{"(A == B", 8, "expected ')', found the end of the expression"},
+		{"A == B C", 8, "unexpected 'C'"},
+		{"url(A).", 8, "expected a field name, found the end of the expression"},
+	}
+
+	for _, l := range lines {
+		_, err := Parse(l.Expr)
+
+		var exprErr *Error
+		if !errors.As(err, &exprErr) || exprErr.Column != l.Column || exprErr.Message != l.Message {
+			t.Errorf("Parse() failed: %q :: %v", l.Expr, err)
+		}
+	}
+}
+
+func TestVars(t *testing.T) {
+	e, err := Parse("url(PUBLIC_URL).host == HOST && !empty(PUBLIC_URL) || A < B")
+	if err != nil {
+		t.Errorf("Parse() failed: %v", err)
+		return
+	}
+
+	if vars := Vars(e.Root()); !reflect.DeepEqual(vars, []string{"PUBLIC_URL", "HOST", "A", "B"}) {
+		t.Errorf("Vars() failed: %v", vars)
+	}
+}
@@ -6,15 +6,15 @@ import (
 	"github.com/OJarrisonn/medik/pkg/medik"
 )
 
-func ReportHeader(header string, level int) string {
-	if medik.NoColor {
+func ReportHeader(header string, level int, noColor bool) string {
+	if noColor {
 		return reportHeaderNoColor(header, level)
 	}
 	return reportHeaderColor(header, level)
 }
 
-func ReportStatus(key, message string, level int) string {
-	if medik.NoColor {
+func ReportStatus(key, message string, level int, noColor bool) string {
+	if noColor {
 		return fmt.Sprintf("\t %s  %s", key, message)
 	}
 
@@ -50,8 +50,8 @@ func reportHeaderNoColor(header string, level int) string {
 	return fmt.Sprintf(" %s  %s", title, header)
 }
 
-func EnvironmentHealth(status int) string {
-	if medik.NoColor {
+func EnvironmentHealth(status int, noColor bool) string {
+	if noColor {
 		if status < medik.ERROR {
 			return " Environment Healthy "
 		} else {
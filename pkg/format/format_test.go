@@ -8,11 +8,11 @@ import (
 )
 
 func TestReportHeaderNoColor(t *testing.T) {
-	medik.NoColor = true
+	noColor := true
 	header := "Header"
 	for level := range medik.MAX_LEVEL + 2 {
 		expected := reportHeaderNoColor(header, level)
-		actual := ReportHeader(header, level)
+		actual := ReportHeader(header, level, noColor)
 		if actual != expected {
 			t.Errorf("Expected %s but got %s", expected, actual)
 		}
@@ -24,11 +24,11 @@ func TestReportHeaderNoColor(t *testing.T) {
 }
 
 func TestReportHeaderColor(t *testing.T) {
-	medik.NoColor = false
+	noColor := false
 	header := "Header"
 	for level := range medik.MAX_LEVEL + 2 {
 		expected := reportHeaderColor(header, level)
-		actual := ReportHeader(header, level)
+		actual := ReportHeader(header, level, noColor)
 		if actual != expected {
 			t.Errorf("Expected %s but got %s", expected, actual)
 		}
@@ -40,13 +40,13 @@ func TestReportHeaderColor(t *testing.T) {
 }
 
 func TestEnvironmentHealthNoColor(t *testing.T) {
-	medik.NoColor = true
+	noColor := true
 	for status := range medik.MAX_LEVEL + 2 {
 		expected := " Environment Healthy "
 		if status >= medik.ERROR {
 			expected = " Environment Unhealthy "
 		}
-		actual := EnvironmentHealth(status)
+		actual := EnvironmentHealth(status, noColor)
 		if actual != expected {
 			t.Errorf("Expected %s but got %s", expected, actual)
 		}
@@ -54,13 +54,13 @@ func TestEnvironmentHealthNoColor(t *testing.T) {
 }
 
 func TestEnvironmentHealthColor(t *testing.T) {
-	medik.NoColor = false
+	noColor := false
 	for status := range medik.MAX_LEVEL + 2 {
 		expected := " Environment Healthy "
 		if status >= medik.ERROR {
 			expected = " Environment Unhealthy "
 		}
-		actual := EnvironmentHealth(status)
+		actual := EnvironmentHealth(status, noColor)
 		if actual != expected {
 			t.Errorf("Expected %s but got %s", expected, actual)
 		}
@@ -68,9 +68,9 @@ func TestEnvironmentHealthColor(t *testing.T) {
 }
 
 func TestReportStatusNoColor(t *testing.T) {
-	medik.NoColor = true
+	noColor := true
 	for level := range medik.MAX_LEVEL + 2 {
-		actual := ReportStatus("FOO", "bar baz baz", level)
+		actual := ReportStatus("FOO", "bar baz baz", level, noColor)
 		if !strings.Contains(actual, "FOO") || !strings.Contains(actual, "bar baz baz") {
 			t.Errorf("Expected 'FOO' and 'bar baz baz' but got %s", actual)
 		}
@@ -78,9 +78,9 @@ func TestReportStatusNoColor(t *testing.T) {
 }
 
 func TestReportStatusColor(t *testing.T) {
-	medik.NoColor = false
+	noColor := false
 	for level := range medik.MAX_LEVEL + 2 {
-		actual := ReportStatus("FOO", "bar baz baz", level)
+		actual := ReportStatus("FOO", "bar baz baz", level, noColor)
 		if !strings.Contains(actual, "FOO") || !strings.Contains(actual, "bar baz baz") {
 			t.Errorf("Expected 'FOO' and 'bar baz baz' but got %s", actual)
 		}
@@ -0,0 +1,57 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// The JSON document produced by a medik run
+type jsonOutput struct {
+	Level   string       `json:"level"`
+	Healthy bool         `json:"healthy"`
+	Reports []jsonReport `json:"reports"`
+}
+
+type jsonReport struct {
+	Exam     string       `json:"exam"`
+	Protocol string       `json:"protocol,omitempty"`
+	Level    string       `json:"level"`
+	Duration float64      `json:"duration_ms"`
+	Statuses []jsonStatus `json:"statuses"`
+}
+
+type jsonStatus struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+	Level   string `json:"level"`
+	Origin  string `json:"origin,omitempty"`
+}
+
+// Writes the reports of a run and its overall health as an indented JSON document
+// Every status is included regardless of the verbosity, so consumers can do their own filtering
+func JSON(w io.Writer, status int, reports []exams.Report) error {
+	output := jsonOutput{
+		Level:   medik.LogLevel(status),
+		Healthy: status < medik.ERROR,
+		Reports: make([]jsonReport, len(reports)),
+	}
+
+	for i, report := range reports {
+		data := report.Data()
+		statuses := make([]jsonStatus, len(data.Statuses))
+
+		for j, s := range data.Statuses {
+			statuses[j] = jsonStatus{Key: s.Key, Message: s.Message, Level: medik.LogLevel(s.Level), Origin: s.Origin}
+		}
+
+		output.Reports[i] = jsonReport{Exam: data.Exam, Protocol: data.Protocol, Level: medik.LogLevel(data.Level), Duration: float64(data.Duration.Microseconds()) / 1000, Statuses: statuses}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(output)
+}
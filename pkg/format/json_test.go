@@ -0,0 +1,68 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReport struct {
+	data exams.ReportData
+}
+
+func (r *fakeReport) Level() int {
+	return r.data.Level
+}
+
+func (r *fakeReport) Format(verbosity int, noColor bool) (int, string, string) {
+	return r.data.Level, r.data.Exam, ""
+}
+
+func (r *fakeReport) Data() exams.ReportData {
+	return r.data
+}
+
+func TestJSON(t *testing.T) {
+	reports := []exams.Report{
+		&fakeReport{exams.ReportData{Exam: "env.is-set", Level: medik.OK, Statuses: []exams.Status{
+			{Key: "FOO", Message: "is valid", Level: medik.OK},
+		}}},
+		&fakeReport{exams.ReportData{Exam: "env.int", Level: medik.ERROR, Statuses: []exams.Status{
+			{Key: "BAR", Message: "is not set", Level: medik.ERROR},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	err := JSON(&buf, medik.ERROR, reports)
+	assert.Nil(t, err)
+
+	var decoded jsonOutput
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "ERROR", decoded.Level)
+	assert.False(t, decoded.Healthy)
+	assert.Len(t, decoded.Reports, 2)
+	assert.Equal(t, "env.is-set", decoded.Reports[0].Exam)
+	assert.Equal(t, jsonStatus{Key: "FOO", Message: "is valid", Level: "OK"}, decoded.Reports[0].Statuses[0])
+	assert.Equal(t, "ERROR", decoded.Reports[1].Level)
+	assert.Equal(t, jsonStatus{Key: "BAR", Message: "is not set", Level: "ERROR"}, decoded.Reports[1].Statuses[0])
+}
+
+func TestJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := JSON(&buf, medik.OK, []exams.Report{})
+	assert.Nil(t, err)
+
+	var decoded jsonOutput
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	assert.Nil(t, err)
+
+	assert.True(t, decoded.Healthy)
+	assert.NotNil(t, decoded.Reports)
+	assert.Empty(t, decoded.Reports)
+}
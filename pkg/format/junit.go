@@ -0,0 +1,121 @@
+package format
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// Writes the reports of a run as a JUnit XML document
+// Each report becomes a <testsuite> (prefixed by its protocol, if any) and each status a <testcase>
+// Errors are mapped to <failure>. Warnings are recorded as a `warning` property of the test case,
+// unless `skipWarnings` is set, in which case they're mapped to <skipped>
+func JUnit(w io.Writer, reports []exams.Report, skipWarnings bool) error {
+	output := junitTestSuites{Name: medik.Name, Suites: make([]junitTestSuite, len(reports))}
+
+	for i, report := range reports {
+		data := report.Data()
+		suite := junitTestSuite{
+			Name:      junitSuiteName(data),
+			Time:      strconv.FormatFloat(data.Duration.Seconds(), 'f', 3, 64),
+			TestCases: make([]junitTestCase, len(data.Statuses)),
+		}
+
+		for j, status := range data.Statuses {
+			testCase := junitTestCase{Name: status.Key, ClassName: suite.Name}
+
+			switch {
+			case status.Level >= medik.ERROR:
+				testCase.Failure = &junitMessage{Message: status.Message, Type: medik.LogLevel(status.Level)}
+				suite.Failures++
+			case status.Level == medik.WARNING && skipWarnings:
+				testCase.Skipped = &junitMessage{Message: status.Message}
+				suite.Skipped++
+			case status.Level == medik.WARNING:
+				testCase.Properties = &junitProperties{[]junitProperty{{Name: "warning", Value: status.Message}}}
+			}
+
+			if status.Origin != "" {
+				if testCase.Properties == nil {
+					testCase.Properties = &junitProperties{}
+				}
+				testCase.Properties.Properties = append(testCase.Properties.Properties, junitProperty{Name: "origin", Value: status.Origin})
+			}
+
+			suite.TestCases[j] = testCase
+		}
+
+		suite.Tests = len(suite.TestCases)
+		output.Tests += suite.Tests
+		output.Failures += suite.Failures
+		output.Skipped += suite.Skipped
+		output.Suites[i] = suite
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(output); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Returns the name of the test suite for a report
+// Reports from protocols are prefixed by the protocol name, like `release/env.is-set`
+func junitSuiteName(data exams.ReportData) string {
+	if data.Protocol == "" {
+		return data.Exam
+	}
+
+	return data.Protocol + "/" + data.Exam
+}
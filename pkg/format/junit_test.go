@@ -0,0 +1,61 @@
+package format
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func junitSampleReports() []exams.Report {
+	return []exams.Report{
+		&fakeReport{exams.ReportData{Exam: "env.is-set", Level: medik.ERROR, Statuses: []exams.Status{
+			{Key: "FOO", Message: "is valid", Level: medik.OK},
+			{Key: "BAR", Message: "is not set", Level: medik.ERROR},
+		}}},
+		&exams.RunReport{Protocol: "release", Report: &fakeReport{exams.ReportData{Exam: "env.int", Level: medik.WARNING, Statuses: []exams.Status{
+			{Key: "PORT", Message: "'abc' is not valid", Level: medik.WARNING},
+		}}}},
+	}
+}
+
+func TestJUnitWarningsAsProperties(t *testing.T) {
+	var buf bytes.Buffer
+	err := JUnit(&buf, junitSampleReports(), false)
+	assert.Nil(t, err)
+
+	var decoded junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &decoded)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 3, decoded.Tests)
+	assert.Equal(t, 1, decoded.Failures)
+	assert.Equal(t, 0, decoded.Skipped)
+	assert.Len(t, decoded.Suites, 2)
+
+	assert.Equal(t, "env.is-set", decoded.Suites[0].Name)
+	assert.Nil(t, decoded.Suites[0].TestCases[0].Failure)
+	assert.Equal(t, "is not set", decoded.Suites[0].TestCases[1].Failure.Message)
+
+	assert.Equal(t, "release/env.int", decoded.Suites[1].Name)
+	assert.Nil(t, decoded.Suites[1].TestCases[0].Skipped)
+	assert.Equal(t, "warning", decoded.Suites[1].TestCases[0].Properties.Properties[0].Name)
+}
+
+func TestJUnitWarningsAsSkipped(t *testing.T) {
+	var buf bytes.Buffer
+	err := JUnit(&buf, junitSampleReports(), true)
+	assert.Nil(t, err)
+
+	var decoded junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &decoded)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, decoded.Skipped)
+	assert.Equal(t, 1, decoded.Suites[1].Skipped)
+	assert.Nil(t, decoded.Suites[1].TestCases[0].Properties)
+	assert.Equal(t, "'abc' is not valid", decoded.Suites[1].TestCases[0].Skipped.Message)
+}
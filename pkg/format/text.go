@@ -0,0 +1,33 @@
+package format
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+)
+
+// Writes the reports of a run in a human readable way, followed by the overall health
+// Reports and statuses below `verbosity` are left out
+func Text(w io.Writer, status int, reports []exams.Report, verbosity int, noColor bool) error {
+	for _, e := range reports {
+		level, header, body := e.Format(verbosity, noColor)
+
+		if level < verbosity {
+			continue
+		}
+
+		if _, err := fmt.Fprintln(w, header); err != nil {
+			return err
+		}
+
+		if body != "" {
+			if _, err := fmt.Fprintln(w, body); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, EnvironmentHealth(status, noColor))
+	return err
+}
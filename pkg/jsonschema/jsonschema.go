@@ -0,0 +1,547 @@
+// Package jsonschema validates values against a JSON Schema. It implements the validation keywords most
+// schemas use: `type`, `enum`, `const`, `properties`, `required`, `additionalProperties`, `items`,
+// `minItems`, `maxItems`, `uniqueItems`, `minLength`, `maxLength`, `pattern`, `minimum`, `maximum`,
+// `exclusiveMinimum`, `exclusiveMaximum`, `allOf`, `anyOf`, `oneOf`, `not` and local `$ref`s like
+// `#/$defs/port`. Other keywords are ignored, as the spec requires for unknown ones
+//
+// Values are the ones decoded by encoding/json into an `any`. Use Normalize for the ones decoded from YAML
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// An error to describe an invalid schema
+type SchemaError struct {
+	Pointer string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	return "invalid schema at " + pointerString(e.Pointer) + ": " + e.Message
+}
+
+// A value that doesn't match the schema. Pointer is the JSON pointer (RFC 6901) of the value, "" for the root
+// Value is the value itself formatted like JSON, for the violations that show it (enum, const and the
+// ranges of numbers), and empty for the others
+type Violation struct {
+	Pointer string
+	Message string
+	Value   string
+}
+
+func (v Violation) String() string {
+	if v.Value != "" {
+		return pointerString(v.Pointer) + ": " + v.Message + ", got " + v.Value
+	}
+
+	return pointerString(v.Pointer) + ": " + v.Message
+}
+
+func pointerString(pointer string) string {
+	if pointer == "" {
+		return "the root"
+	}
+
+	return pointer
+}
+
+// A compiled schema
+type Schema struct {
+	root     any
+	patterns map[string]*regexp.Regexp
+	// The `$ref`s already compiled, so recursive schemas are compiled once
+	refs map[string]bool
+}
+
+var types = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+// Compiles a schema, which is a bool or an object decoded from JSON or YAML
+func Compile(schema any) (*Schema, error) {
+	s := &Schema{root: Normalize(schema), patterns: map[string]*regexp.Regexp{}, refs: map[string]bool{}}
+
+	if err := s.compile(s.root, ""); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Checks the keywords of `schema` and of its subschemas, compiling their patterns
+func (s *Schema) compile(schema any, pointer string) error {
+	if _, ok := schema.(bool); ok {
+		return nil
+	}
+
+	keywords, ok := schema.(map[string]any)
+	if !ok {
+		return &SchemaError{Pointer: pointer, Message: "expected an object or a boolean"}
+	}
+
+	if ty, ok := keywords["type"]; ok {
+		names, ok := ty.([]any)
+		if !ok {
+			names = []any{ty}
+		}
+
+		for _, name := range names {
+			if name, ok := name.(string); !ok || !slices.Contains(types, name) {
+				return &SchemaError{Pointer: pointer + "/type", Message: fmt.Sprintf("unknown type %v", name)}
+			}
+		}
+	}
+
+	if pattern, ok := keywords["pattern"]; ok {
+		str, ok := pattern.(string)
+		if !ok {
+			return &SchemaError{Pointer: pointer + "/pattern", Message: "expected a string"}
+		}
+
+		regex, err := regexp.Compile(str)
+		if err != nil {
+			return &SchemaError{Pointer: pointer + "/pattern", Message: err.Error()}
+		}
+		s.patterns[str] = regex
+	}
+
+	if ref, ok := keywords["$ref"]; ok {
+		str, ok := ref.(string)
+		if !ok {
+			return &SchemaError{Pointer: pointer + "/$ref", Message: "expected a string"}
+		}
+
+		sub, err := s.resolve(str)
+		if err != nil {
+			return &SchemaError{Pointer: pointer + "/$ref", Message: err.Error()}
+		}
+
+		// The reference may point outside of the keywords walked below
+		if !s.refs[str] {
+			s.refs[str] = true
+			if err := s.compile(sub, str[1:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, keyword := range []string{"minItems", "maxItems", "minLength", "maxLength", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum"} {
+		if value, ok := keywords[keyword]; ok {
+			if _, ok := value.(float64); !ok {
+				return &SchemaError{Pointer: pointer + "/" + keyword, Message: "expected a number"}
+			}
+		}
+	}
+
+	if required, ok := keywords["required"]; ok {
+		names, ok := required.([]any)
+		if !ok {
+			return &SchemaError{Pointer: pointer + "/required", Message: "expected an array of strings"}
+		}
+
+		for _, name := range names {
+			if _, ok := name.(string); !ok {
+				return &SchemaError{Pointer: pointer + "/required", Message: "expected an array of strings"}
+			}
+		}
+	}
+
+	if enum, ok := keywords["enum"]; ok {
+		if _, ok := enum.([]any); !ok {
+			return &SchemaError{Pointer: pointer + "/enum", Message: "expected an array"}
+		}
+	}
+
+	// Subschemas
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := keywords[keyword]; ok {
+			if err := s.compile(sub, pointer+"/"+keyword); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, keyword := range []string{"properties", "$defs", "definitions"} {
+		if subs, ok := keywords[keyword]; ok {
+			props, ok := subs.(map[string]any)
+			if !ok {
+				return &SchemaError{Pointer: pointer + "/" + keyword, Message: "expected an object"}
+			}
+
+			for _, name := range sortedNames(props) {
+				if err := s.compile(props[name], pointer+"/"+keyword+"/"+escape(name)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if subs, ok := keywords[keyword]; ok {
+			list, ok := subs.([]any)
+			if !ok || len(list) == 0 {
+				return &SchemaError{Pointer: pointer + "/" + keyword, Message: "expected a non empty array"}
+			}
+
+			for i, sub := range list {
+				if err := s.compile(sub, pointer+"/"+keyword+"/"+strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the subschema a local `$ref`, like `#/$defs/port`, points to
+func (s *Schema) resolve(ref string) (any, error) {
+	if ref != "#" && !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("only local references like '#/$defs/name' are supported, got '%v'", ref)
+	}
+
+	schema := s.root
+
+	for _, token := range strings.Split(ref, "/")[1:] {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch node := schema.(type) {
+		case map[string]any:
+			sub, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("'%v' doesn't exist", ref)
+			}
+			schema = sub
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("'%v' doesn't exist", ref)
+			}
+			schema = node[i]
+		default:
+			return nil, fmt.Errorf("'%v' doesn't exist", ref)
+		}
+	}
+
+	return schema, nil
+}
+
+// Validates a value, returning every violation found, sorted by their pointers
+func (s *Schema) Validate(value any) []Violation {
+	violations := s.validate(s.root, Normalize(value), "", 0)
+
+	slices.SortStableFunc(violations, func(a, b Violation) int {
+		return strings.Compare(a.Pointer, b.Pointer)
+	})
+
+	return violations
+}
+
+// The maximum number of nested `$ref`s followed, to stop on recursive schemas that never consume the value
+const maxDepth = 64
+
+func (s *Schema) validate(schema any, value any, pointer string, depth int) []Violation {
+	if b, ok := schema.(bool); ok {
+		if !b {
+			return []Violation{{Pointer: pointer, Message: "no value is allowed"}}
+		}
+
+		return nil
+	}
+
+	keywords := schema.(map[string]any)
+	violations := []Violation{}
+	fail := func(format string, args ...any) {
+		violations = append(violations, Violation{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+	// Same as fail, showing `value` after the message
+	failValue := func(message string, args ...any) {
+		violations = append(violations, Violation{Pointer: pointer, Message: fmt.Sprintf(message, args...), Value: format(value)})
+	}
+
+	if ref, ok := keywords["$ref"].(string); ok {
+		if depth >= maxDepth {
+			fail("too many nested references")
+			return violations
+		}
+
+		sub, _ := s.resolve(ref)
+		violations = append(violations, s.validate(sub, value, pointer, depth+1)...)
+	}
+
+	if ty, ok := keywords["type"]; ok {
+		names, ok := ty.([]any)
+		if !ok {
+			names = []any{ty}
+		}
+
+		if !slices.ContainsFunc(names, func(name any) bool { return hasType(value, name.(string)) }) {
+			expected := make([]string, len(names))
+			for i, name := range names {
+				expected[i] = name.(string)
+			}
+
+			// The other keywords would only repeat the same problem
+			fail("expected %v, got %v", strings.Join(expected, " or "), typeOf(value))
+			return violations
+		}
+	}
+
+	if enum, ok := keywords["enum"].([]any); ok && !slices.ContainsFunc(enum, func(option any) bool { return equal(option, value) }) {
+		options := make([]string, len(enum))
+		for i, option := range enum {
+			options[i] = format(option)
+		}
+
+		failValue("expected one of %v", strings.Join(options, ", "))
+	}
+
+	if c, ok := keywords["const"]; ok && !equal(c, value) {
+		failValue("expected %v", format(c))
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := float64(utf8.RuneCountInString(v))
+
+		if min, ok := keywords["minLength"].(float64); ok && length < min {
+			fail("expected at least %v characters, got %v", min, length)
+		}
+
+		if max, ok := keywords["maxLength"].(float64); ok && length > max {
+			fail("expected at most %v characters, got %v", max, length)
+		}
+
+		if pattern, ok := keywords["pattern"].(string); ok && !s.patterns[pattern].MatchString(v) {
+			fail("should match the pattern %v", pattern)
+		}
+	case float64:
+		if min, ok := keywords["minimum"].(float64); ok && v < min {
+			failValue("expected a number >= %v", min)
+		}
+
+		if max, ok := keywords["maximum"].(float64); ok && v > max {
+			failValue("expected a number <= %v", max)
+		}
+
+		if min, ok := keywords["exclusiveMinimum"].(float64); ok && v <= min {
+			failValue("expected a number > %v", min)
+		}
+
+		if max, ok := keywords["exclusiveMaximum"].(float64); ok && v >= max {
+			failValue("expected a number < %v", max)
+		}
+	case []any:
+		if min, ok := keywords["minItems"].(float64); ok && float64(len(v)) < min {
+			fail("expected at least %v items, got %v", min, len(v))
+		}
+
+		if max, ok := keywords["maxItems"].(float64); ok && float64(len(v)) > max {
+			fail("expected at most %v items, got %v", max, len(v))
+		}
+
+		if unique, ok := keywords["uniqueItems"].(bool); ok && unique {
+			for i := range v {
+				for j := range i {
+					if equal(v[i], v[j]) {
+						fail("items %v and %v are equal", j, i)
+					}
+				}
+			}
+		}
+
+		if items, ok := keywords["items"]; ok {
+			for i, item := range v {
+				violations = append(violations, s.validate(items, item, pointer+"/"+strconv.Itoa(i), depth)...)
+			}
+		}
+	case map[string]any:
+		if required, ok := keywords["required"].([]any); ok {
+			for _, name := range required {
+				if _, ok := v[name.(string)]; !ok {
+					violations = append(violations, Violation{Pointer: pointer + "/" + escape(name.(string)), Message: "is required"})
+				}
+			}
+		}
+
+		properties, _ := keywords["properties"].(map[string]any)
+		additional, hasAdditional := keywords["additionalProperties"]
+
+		for _, name := range sortedNames(v) {
+			if sub, ok := properties[name]; ok {
+				violations = append(violations, s.validate(sub, v[name], pointer+"/"+escape(name), depth)...)
+			} else if hasAdditional {
+				if b, ok := additional.(bool); ok && !b {
+					violations = append(violations, Violation{Pointer: pointer + "/" + escape(name), Message: "is not an allowed property"})
+				} else {
+					violations = append(violations, s.validate(additional, v[name], pointer+"/"+escape(name), depth)...)
+				}
+			}
+		}
+	}
+
+	if all, ok := keywords["allOf"].([]any); ok {
+		for _, sub := range all {
+			violations = append(violations, s.validate(sub, value, pointer, depth)...)
+		}
+	}
+
+	if alternatives, ok := keywords["anyOf"].([]any); ok && s.matches(alternatives, value, pointer, depth) == 0 {
+		fail("should match at least one of the schemas of anyOf")
+	}
+
+	if one, ok := keywords["oneOf"].([]any); ok {
+		if matches := s.matches(one, value, pointer, depth); matches != 1 {
+			fail("should match exactly one of the schemas of oneOf, but matches %v", matches)
+		}
+	}
+
+	if not, ok := keywords["not"]; ok && len(s.validate(not, value, pointer, depth)) == 0 {
+		fail("should not match the schema of not")
+	}
+
+	return violations
+}
+
+// Returns how many of the schemas `value` matches
+func (s *Schema) matches(schemas []any, value any, pointer string, depth int) int {
+	matches := 0
+
+	for _, sub := range schemas {
+		if len(s.validate(sub, value, pointer, depth)) == 0 {
+			matches++
+		}
+	}
+
+	return matches
+}
+
+func hasType(value any, ty string) bool {
+	switch ty {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	}
+
+	return typeOf(value) == ty
+}
+
+// Returns the JSON type of a normalized value
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	}
+
+	return "object"
+}
+
+func equal(a, b any) bool {
+	return reflect.DeepEqual(Normalize(a), Normalize(b))
+}
+
+// Formats a value for a violation, like JSON does for scalars
+func format(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	return "an " + typeOf(value)
+}
+
+// Converts a value decoded from YAML (or built in Go) to the types encoding/json decodes: numbers become
+// float64, maps become map[string]any and slices []any. Timestamps become RFC 3339 strings
+func Normalize(value any) any {
+	switch v := value.(type) {
+	case nil, bool, string, float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = Normalize(item)
+		}
+		return list
+	case map[string]any:
+		object := make(map[string]any, len(v))
+		for key, item := range v {
+			object[key] = Normalize(item)
+		}
+		return object
+	case map[any]any:
+		object := make(map[string]any, len(v))
+		for key, item := range v {
+			object[fmt.Sprint(key)] = Normalize(item)
+		}
+		return object
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		list := make([]any, rv.Len())
+		for i := range list {
+			list[i] = Normalize(rv.Index(i).Interface())
+		}
+		return list
+	case reflect.Map:
+		object := make(map[string]any, rv.Len())
+		for _, key := range rv.MapKeys() {
+			object[fmt.Sprint(key.Interface())] = Normalize(rv.MapIndex(key).Interface())
+		}
+		return object
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Escapes a property name to be used in a JSON pointer
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+func sortedNames(m map[string]any) []string {
+	names := make([]string, 0, len(m))
+
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,120 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func compile(t *testing.T, schema string) *Schema {
+	var raw any
+	assert.Nil(t, yaml.Unmarshal([]byte(schema), &raw))
+
+	s, err := Compile(raw)
+	assert.Nil(t, err)
+
+	return s
+}
+
+func validate(t *testing.T, s *Schema, value string) []string {
+	var raw any
+	assert.Nil(t, json.Unmarshal([]byte(value), &raw))
+
+	violations := []string{}
+	for _, v := range s.Validate(raw) {
+		violations = append(violations, v.String())
+	}
+
+	return violations
+}
+
+func TestValidate(t *testing.T) {
+	s := compile(t, `
+type: object
+required: [name, flags]
+additionalProperties: false
+properties:
+  name: {type: string, minLength: 3, pattern: "^[a-z]+$"}
+  replicas: {type: integer, minimum: 1, maximum: 10}
+  flags:
+    type: object
+    additionalProperties: {type: boolean}
+  tags:
+    type: array
+    items: {enum: [a, b]}
+    uniqueItems: true
+    maxItems: 2
+  a/b~c: {const: 1}
+`)
+
+	assert.Empty(t, validate(t, s, `{"name": "medik", "flags": {"a": true}, "replicas": 3, "tags": ["a", "b"]}`))
+	assert.Equal(t, []string{
+		"/a~1b~0c: expected 1, got 2",
+		"/extra: is not an allowed property",
+		"/flags: is required",
+		"/name: expected at least 3 characters, got 2",
+		"/name: should match the pattern ^[a-z]+$",
+		"/replicas: expected integer, got number",
+		"/tags: expected at most 2 items, got 3",
+		"/tags: items 0 and 2 are equal",
+		"/tags/1: expected one of \"a\", \"b\", got \"c\"",
+	}, validate(t, s, `{"name": "M1", "replicas": 1.5, "tags": ["a", "c", "a"], "extra": 1, "a/b~c": 2}`))
+	assert.Equal(t, []string{"the root: expected object, got array"}, validate(t, s, `[]`))
+
+	// The values shown by a violation are kept apart from its message
+	assert.Equal(t, []Violation{{Pointer: "/replicas", Message: "expected a number <= 10", Value: "11"}}, s.Validate(map[string]any{"name": "medik", "flags": map[string]any{}, "replicas": 11}))
+}
+
+func TestValidateCombinators(t *testing.T) {
+	s := compile(t, `
+$defs:
+  port: {type: integer, exclusiveMinimum: 0, exclusiveMaximum: 65536}
+type: array
+items:
+  oneOf:
+    - $ref: "#/$defs/port"
+    - {type: string, not: {const: ""}}
+`)
+
+	assert.Empty(t, validate(t, s, `[80, "http"]`))
+	assert.Equal(t, []string{
+		"/0: should match exactly one of the schemas of oneOf, but matches 0",
+		"/1: should match exactly one of the schemas of oneOf, but matches 0",
+		"/2: should match exactly one of the schemas of oneOf, but matches 0",
+	}, validate(t, s, `[0, "", null]`))
+
+	s = compile(t, `anyOf: [{type: "null"}, {type: [number, boolean], allOf: [{minimum: 1}]}]`)
+	assert.Empty(t, validate(t, s, `null`))
+	assert.Empty(t, validate(t, s, `true`))
+	assert.Equal(t, []string{"the root: should match at least one of the schemas of anyOf"}, validate(t, s, `0`))
+}
+
+func TestCompileErrors(t *testing.T) {
+	schemas := map[string]string{
+		`type: text`:                        "invalid schema at /type: unknown type text",
+		`pattern: "["`:                      "invalid schema at /pattern: error parsing regexp: missing closing ]: `[`",
+		`properties: {a: {$ref: "#/nope"}}`: "invalid schema at /properties/a/$ref: '#/nope' doesn't exist",
+		`items: {$ref: "other.json"}`:       "invalid schema at /items/$ref: only local references like '#/$defs/name' are supported, got 'other.json'",
+		`anyOf: []`:                         "invalid schema at /anyOf: expected a non empty array",
+		`- 1`:                               "invalid schema at the root: expected an object or a boolean",
+	}
+
+	for schema, message := range schemas {
+		var raw any
+		assert.Nil(t, yaml.Unmarshal([]byte(schema), &raw))
+
+		_, err := Compile(raw)
+		if assert.NotNil(t, err, schema) {
+			assert.Equal(t, message, err.Error())
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	var raw any
+	assert.Nil(t, yaml.Unmarshal([]byte("a: 1\nb: [true, 2.5]\n1: x\n"), &raw))
+
+	assert.Equal(t, map[string]any{"a": 1.0, "b": []any{true, 2.5}, "1": "x"}, Normalize(raw))
+}
@@ -15,9 +15,18 @@ const (
 
 const (
 	DefaultConfigFile = "medik.yaml"
-	DefaultEnvFile    = ""
 	DefaultVerbosity  = 1
 	DefaultNoColor    = false
+	DefaultOutput     = OutputText
+	DefaultOutputFile = ""
+	// Zero means the number of jobs is taken from the config file
+	DefaultJobs = 0
+)
+
+const (
+	OutputText  = "text"
+	OutputJSON  = "json"
+	OutputJUnit = "junit"
 )
 
 const (
@@ -61,10 +70,3 @@ var (
 	WarningColor       = color.New(color.FgYellow)
 	SuccessColor       = color.New(color.FgGreen)
 )
-
-var (
-	ConfigFile string
-	EnvFile    string
-	Verbosity  int = DefaultVerbosity
-	NoColor    bool
-)
@@ -3,23 +3,32 @@ package parse
 import (
 	"strings"
 
-	"github.com/OJarrisonn/medik/pkg/config"
 	"github.com/OJarrisonn/medik/pkg/exams"
-	"github.com/OJarrisonn/medik/pkg/exams/env"
-	"github.com/OJarrisonn/medik/pkg/exams/file"
+	"github.com/OJarrisonn/medik/pkg/exams/plugin"
+
+	// Built-in exam categories, they register themselves on init
+	_ "github.com/OJarrisonn/medik/pkg/exams/bin"
+	_ "github.com/OJarrisonn/medik/pkg/exams/cmd"
+	_ "github.com/OJarrisonn/medik/pkg/exams/env"
+	_ "github.com/OJarrisonn/medik/pkg/exams/file"
+	_ "github.com/OJarrisonn/medik/pkg/exams/service"
 )
 
 // Returns the parser for a given type
 // A type is a string in the format `category.kind` which identifies which exam will be parsed
-// Returns the parser and a boolean indicating if the parser was found
-func GetExamParser(ty string) (func(config config.Exam) (exams.Exam, error), bool) {
+// Categories are looked up in the exams registry, which includes the built-in ones and any registered
+// with exams.Register. Returns the parser and a boolean indicating if the parser was found
+func GetExamParser(ty string) (exams.Parser, bool) {
+	return exams.GetParser(ty)
+}
+
+// Same as GetExamParser, but the exams of the categories in `plugins` are run by those plugins
+func GetExamParserWithPlugins(ty string, plugins map[string]*plugin.Plugin) (exams.Parser, bool) {
 	category, _, _ := strings.Cut(ty, ".")
-	switch category {
-	case "env":
-		return env.GetParser(ty)
-	case "file":
-		return file.GetParser(ty)
-	default:
-		return nil, false
+
+	if p, ok := plugins[category]; ok {
+		return p.GetParser(ty)
 	}
+
+	return GetExamParser(ty)
 }
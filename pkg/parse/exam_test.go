@@ -0,0 +1,21 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinCategories(t *testing.T) {
+	assert.Subset(t, exams.Categories(), []string{"bin", "cmd", "env", "file", "service"})
+
+	_, ok := GetExamParser("env.is-set")
+	assert.True(t, ok)
+
+	_, ok = GetExamParser("env.inexistent")
+	assert.False(t, ok)
+
+	// Built-in categories can't be replaced
+	assert.NotNil(t, exams.Register("env", exams.Parsers{}.Get))
+}
@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"os"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/parse"
+	"gopkg.in/yaml.v3"
+)
+
+// An error to describe an env file that can't be read or parsed
+type EnvFileError struct {
+	Path string
+	Err  error
+}
+
+func (e *EnvFileError) Error() string {
+	return "invalid env file '" + e.Path + "': " + e.Err.Error()
+}
+
+func (e *EnvFileError) Unwrap() error {
+	return e.Err
+}
+
+// Reads the env file at `path`, relative to `dir`, expanding the variables it doesn't define with the ones of `env`
+// The file keeps `path` as it's written, so reports show it like that
+func loadEnvFile(dir, path string, env environment.Source) (*environment.File, error) {
+	content, err := os.ReadFile(config.ResolvePath(dir, path))
+	if err != nil {
+		return nil, &EnvFileError{Path: path, Err: err}
+	}
+
+	vars, err := parse.ParseEnvFileWithLookup(string(content), env.LookupEnv)
+	if err != nil {
+		return nil, &EnvFileError{Path: path, Err: err}
+	}
+
+	return &environment.File{Path: path, Vars: vars}, nil
+}
+
+// Loads `files` in order on top of `env`, so later files win. Each file is expanded with the layers below it
+// `node` is the mapping with the `env-files` key, used to report where the invalid files are declared
+func (p *configParser) loadEnvFiles(env environment.Layered, files []string, node *yaml.Node, protocol string) environment.Layered {
+	if len(files) == 0 {
+		return env
+	}
+
+	// Don't share the backing array with other protocols stacked on the same layers
+	layers := append(environment.Layered{}, env...)
+
+	for i, path := range files {
+		file, err := loadEnvFile(p.dir, path, layers)
+		if err != nil {
+			line, column := sequenceItemPosition(mappingValue(node, "env-files"), i)
+			p.problems.Errors = append(p.problems.Errors, &ExamError{Protocol: protocol, Index: -1, Line: line, Column: column, Err: err})
+			continue
+		}
+
+		layers = append(layers, file)
+	}
+
+	return layers
+}
+
+// Returns the environment of exams that examine `env`, with the overrides on top of it
+// and the secret variables of the config
+func (p *configParser) environment(env environment.Layered) environment.Source {
+	var source environment.Source = env[0]
+
+	if len(env) > 1 || len(p.overrides) > 0 {
+		source = append(append(environment.Layered{}, env...), p.overrides...)
+	}
+
+	return environment.WithSecrets(source, p.secrets, p.showSecrets)
+}
+
+// Returns the position of the i-th item of a sequence node, or 0, 0 if it's not there
+func sequenceItemPosition(node *yaml.Node, i int) (int, int) {
+	if node == nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+		return 0, 0
+	}
+
+	return node.Content[i].Line, node.Content[i].Column
+}
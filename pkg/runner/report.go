@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/format"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// A report for an exam that didn't finish, because it timed out or the run was cancelled
+type InterruptedReport struct {
+	Type    string
+	Lvl     int
+	Message string
+}
+
+// Creates the report of an interrupted job from the error returned by exams.Examinate
+// Timed out exams keep their level, while cancelled ones are always errors since the run is incomplete
+func interruptedReport(j job, err error) *InterruptedReport {
+	if errors.Is(err, context.DeadlineExceeded) && j.timeout > 0 {
+		return &InterruptedReport{Type: j.ty, Lvl: j.level, Message: fmt.Sprintf("timed out after %v", j.timeout)}
+	}
+
+	return &InterruptedReport{Type: j.ty, Lvl: medik.ERROR, Message: "cancelled before finishing: " + err.Error()}
+}
+
+func (r *InterruptedReport) Level() int {
+	return r.Lvl
+}
+
+func (r *InterruptedReport) Format(verbosity int, noColor bool) (int, string, string) {
+	status := ""
+
+	if r.Lvl >= verbosity {
+		status = format.ReportStatus(r.Type, r.Message, r.Lvl, noColor) + "\n"
+	}
+
+	return r.Lvl, format.ReportHeader(r.Type, r.Lvl, noColor), status
+}
+
+func (r *InterruptedReport) Data() exams.ReportData {
+	return exams.ReportData{
+		Exam:     r.Type,
+		Level:    r.Lvl,
+		Statuses: []exams.Status{{Key: r.Type, Message: r.Message, Level: r.Lvl}},
+	}
+}
+
+// A report with the warnings found while decoding the config, like fields not used by an exam
+type ConfigReport struct {
+	Lvl      int
+	Warnings []*ExamError
+}
+
+func newConfigReport(warnings []*ExamError) *ConfigReport {
+	return &ConfigReport{Lvl: medik.WARNING, Warnings: warnings}
+}
+
+func (r *ConfigReport) Level() int {
+	return r.Lvl
+}
+
+func (r *ConfigReport) Format(verbosity int, noColor bool) (int, string, string) {
+	statuses := ""
+
+	if r.Lvl >= verbosity {
+		for _, warning := range r.Warnings {
+			statuses += format.ReportStatus(warning.Location(), warning.Err.Error(), r.Lvl, noColor) + "\n"
+		}
+	}
+
+	return r.Lvl, format.ReportHeader("config", r.Lvl, noColor), statuses
+}
+
+func (r *ConfigReport) Data() exams.ReportData {
+	statuses := make([]exams.Status, len(r.Warnings))
+
+	for i, warning := range r.Warnings {
+		statuses[i] = exams.Status{Key: warning.Location(), Message: warning.Err.Error(), Level: r.Lvl}
+	}
+
+	return exams.ReportData{Exam: "config", Level: r.Lvl, Statuses: statuses}
+}
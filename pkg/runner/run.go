@@ -1,11 +1,19 @@
 package runner
 
 import (
+	"context"
 	"fmt"
+	"path"
+	"path/filepath"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
 	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/exams/plugin"
 	"github.com/OJarrisonn/medik/pkg/medik"
 	"github.com/OJarrisonn/medik/pkg/parse"
 )
@@ -18,74 +26,293 @@ func (e *UnknownExamError) Error() string {
 	return fmt.Sprintf("unknown exam: %v", e.ExamType)
 }
 
+// An exam ready to be run, the protocol it was declared in (empty for top-level exams),
+// the environment it examines and the settings needed to report it if it's interrupted.
+// A zero timeout means no timeout
+type job struct {
+	exam     exams.Exam
+	ty       string
+	protocol string
+	env      environment.Source
+	level    int
+	timeout  time.Duration
+}
+
+// Same as RunContext, but the run can't be cancelled
 func Run(config *config.Medik, protocols []string) (int, []exams.Report, error) {
-	examsSuccesses, examsReports, examsError := runExams(config.Exams)
+	return RunContext(context.Background(), config, protocols)
+}
+
+// Same as RunEnv, examinating the process environment
+func RunContext(ctx context.Context, config *config.Medik, protocols []string) (int, []exams.Report, error) {
+	return RunEnv(ctx, config, protocols, environment.Process)
+}
 
-	if examsError != nil {
-		return medik.ERROR, nil, examsError
+// Runs the top-level exams and the exams of the given protocols against the variables of `env`, with the
+// env files of the config on top of it
+// Every exam is parsed before any of them runs, so an invalid config fails without running anything
+// Up to `config.Jobs` exams run at the same time (at least one), but the reports are always returned
+// in the order the exams are declared, followed by the protocols in the order they were requested
+// Exams that exceed their timeout (or `config.Timeout`) or are still running when ctx is cancelled
+// are reported with an InterruptedReport. Config warnings found by strict decoding come first as a ConfigReport
+func RunEnv(ctx context.Context, config *config.Medik, protocols []string, env environment.Source) (int, []exams.Report, error) {
+	return run(ctx, config, protocols, env, nil)
+}
+
+// Same as RunEnv, with `overrides` on top of the env files of the config
+func run(ctx context.Context, config *config.Medik, protocols []string, env environment.Source, overrides environment.Layered) (int, []exams.Report, error) {
+	jobs, problems := parseConfig(config, protocols, env, overrides)
+	if len(problems.Errors) > 0 {
+		return medik.ERROR, nil, problems
 	}
 
-	protocolsUsed := config.Protocols
+	reports := runJobs(ctx, jobs, config.Jobs)
 
-	for k := range protocolsUsed {
-		if !slices.Contains(protocols, k) {
-			delete(protocolsUsed, k)
-		}
+	if len(problems.Warnings) > 0 {
+		reports = append([]exams.Report{newConfigReport(problems.Warnings)}, reports...)
 	}
 
-	protocolsSuccess, protocolsReports, protocolsError := runProtocols(protocolsUsed)
+	success := medik.OK
 
-	if protocolsError != nil {
-		return medik.ERROR, nil, protocolsError
+	for _, report := range reports {
+		if report.Level() > success {
+			success = report.Level()
+		}
 	}
 
-	success := max(examsSuccesses, protocolsSuccess)
+	return success, reports, nil
+}
 
-	return success, append(examsReports, protocolsReports...), nil
+// The state shared while parsing the exams of a config
+type configParser struct {
+	defaultTimeout time.Duration
+	strict         bool
+	// The directory of the config file, which relative paths are resolved against
+	dir string
+	// The paths of the fields of every registered exam, only needed by strict decoding
+	examPaths []string
+	plugins   map[string]*plugin.Plugin
+	problems  *ConfigError
+	// The environment of the top-level exams, without the overrides
+	env       environment.Layered
+	overrides environment.Layered
+	// The patterns of the secret variables and if their values are shown anyway
+	secrets     []string
+	showSecrets bool
 }
 
-func runExams(exs []config.Exam) (int, []exams.Report, error) {
-	reports := []exams.Report{}
-	success := medik.OK
+// Parses the top-level exams and the exams of the protocols listed in `names`, in the given order
+// Names that aren't declared or repeated are ignored
+// The exams examine `env` with the env files of the config and then `overrides` on top of it
+// Every problem is collected in the returned *ConfigError, instead of stopping on the first one
+func parseConfig(config *config.Medik, names []string, env environment.Source, overrides environment.Layered) ([]job, *ConfigError) {
+	p := &configParser{strict: config.IsStrict(), dir: config.Dir, problems: &ConfigError{}, overrides: overrides}
 
-	for _, v := range exs {
-		if parse, ok := parse.GetExamParser(v.Type); !ok {
-			return medik.ERROR, nil, &UnknownExamError{ExamType: v.Type}
-		} else {
-			exam, err := parse(v)
-			if err != nil {
-				return medik.ERROR, nil, err
-			}
+	if p.strict {
+		checkTopLevelKeys(config, p.problems)
+		p.examPaths = registeredExamPaths()
+	}
 
-			report := exam.Examinate()
-			if report.Level() > success {
-				success = report.Level()
-			}
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil || timeout <= 0 {
+			line, column := configPosition(config, "timeout")
+			p.problems.Errors = append(p.problems.Errors, &ExamError{Index: -1, Line: line, Column: column, Err: fmt.Errorf("invalid timeout '%v': expected a positive duration like 10s", config.Timeout)})
+		}
+		p.defaultTimeout = timeout
+	}
+
+	p.plugins = parsePlugins(config, p.problems)
+	p.secrets = parseSecrets(config, p.problems)
+	p.showSecrets = config.ShowSecrets
+	p.env = p.loadEnvFiles(environment.Layered{env}, config.EnvFiles, config.Node, "")
+
+	jobs := p.parseExams(config.Exams, "", p.environment(p.env))
+	parsed := map[string]bool{}
 
-			reports = append(reports, report)
+	for _, name := range names {
+		protocol, ok := config.Protocols[name]
+		if !ok || parsed[name] {
+			continue
 		}
+
+		parsed[name] = true
+
+		if p.strict {
+			checkProtocolKeys(config, name, p.problems)
+		}
+
+		env := p.loadEnvFiles(p.env, protocol.EnvFiles, config.ProtocolNode(name), name)
+		jobs = append(jobs, p.parseExams(protocol.Exams, name, p.environment(env))...)
 	}
 
-	return success, reports, nil
+	return jobs, p.problems
 }
 
-func runProtocols(protocols map[string]config.Protocol) (int, []exams.Report, error) {
-	reports := []exams.Report{}
-	success := medik.OK
+// Parses a list of exams that examine `env`, appending every problem found to `p.problems`
+func (p *configParser) parseExams(exs []config.Exam, protocol string, env environment.Source) []job {
+	jobs := []job{}
+
+	for i := range exs {
+		v := &exs[i]
+		if v.Dir == "" {
+			v.Dir = p.dir
+		}
+
+		parse, ok := parse.GetExamParserWithPlugins(v.Type, p.plugins)
+		if !ok {
+			p.problems.Errors = append(p.problems.Errors, newExamError(v, protocol, i, &UnknownExamError{ExamType: v.Type}))
+			continue
+		}
 
-	for _, p := range protocols {
-		examsSuccess, examsReports, examsError := runExams(p.Exams)
+		exam, err := parse(*v)
+		if err != nil {
+			p.problems.Errors = append(p.problems.Errors, newExamError(v, protocol, i, err))
+		}
+
+		// Checked even if the exam is invalid, since a typo is often the reason
+		if p.strict && !checkExamKeys(v, protocol, i, p.examPaths, p.problems) || err != nil {
+			continue
+		}
 
-		if examsError != nil {
-			return medik.ERROR, nil, examsError
+		timeout, err := parseTimeout(*v, p.defaultTimeout)
+		if err != nil {
+			p.problems.Errors = append(p.problems.Errors, newExamError(v, protocol, i, err))
+			continue
 		}
 
-		reports = append(reports, examsReports...)
+		jobs = append(jobs, job{exam: exam, ty: v.Type, protocol: protocol, env: env, level: medik.LogLevelFromStr(v.Level), timeout: timeout})
+	}
+
+	return jobs
+}
+
+// Returns the patterns of the secret variables of the config, appending an error for each invalid one
+func parseSecrets(cfg *config.Medik, problems *ConfigError) []string {
+	patterns := cfg.SecretPatterns()
 
-		if examsSuccess > success {
-			success = examsSuccess
+	for i, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			line, column := sequenceItemPosition(mappingValue(cfg.Node, "secrets"), i)
+			problems.Errors = append(problems.Errors, &ExamError{Index: -1, Line: line, Column: column, Err: fmt.Errorf("invalid secret pattern '%v': %w", pattern, err)})
 		}
 	}
 
-	return success, reports, nil
+	return patterns
+}
+
+// Creates the plugins declared in the config, appending an error for each invalid one
+func parsePlugins(cfg *config.Medik, problems *ConfigError) map[string]*plugin.Plugin {
+	plugins := map[string]*plugin.Plugin{}
+	registered := exams.Categories()
+
+	// Sorted, so errors are reported in a stable order
+	categories := make([]string, 0, len(cfg.Plugins))
+	for category := range cfg.Plugins {
+		categories = append(categories, category)
+	}
+	slices.Sort(categories)
+
+	for _, category := range categories {
+		path := cfg.Plugins[category]
+		var err error
+
+		switch {
+		case category == "" || strings.ContainsAny(category, ". \t\n"):
+			err = &PluginError{Category: category, Message: "category names can't be empty or have dots or spaces"}
+		case slices.Contains(registered, category):
+			err = &PluginError{Category: category, Message: "it's a built-in category"}
+		case strings.TrimSpace(path) == "":
+			err = &PluginError{Category: category, Message: "missing the path to its executable"}
+		}
+
+		if err != nil {
+			line, column := configPosition(cfg, "plugins", category)
+			problems.Errors = append(problems.Errors, &ExamError{Index: -1, Line: line, Column: column, Err: err})
+			continue
+		}
+
+		plugins[category] = &plugin.Plugin{Category: category, Path: pluginPath(cfg.Dir, path)}
+	}
+
+	return plugins
+}
+
+// Resolves the path of a plugin executable against `dir`, the directory of the config file
+// Absolute paths and bare names, which are looked up in PATH, are kept as they are
+func pluginPath(dir, path string) string {
+	if !strings.ContainsRune(path, filepath.Separator) && !strings.Contains(path, "/") {
+		return path
+	}
+
+	return config.ResolvePath(dir, path)
+}
+
+// An error to describe an invalid entry of the `plugins` section of the config
+type PluginError struct {
+	Category,
+	Message string
+}
+
+func (e *PluginError) Error() string {
+	return "invalid plugin '" + e.Category + "': " + e.Message
+}
+
+// Runs the jobs using a pool of `workers` goroutines
+// Each report is stored at the same index of its job, so the order doesn't depend on scheduling
+func runJobs(ctx context.Context, jobs []job, workers int) []exams.Report {
+	reports := make([]exams.Report, len(jobs))
+	queue := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for range max(1, min(workers, len(jobs))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				start := time.Now()
+				report := runJob(ctx, jobs[i])
+				reports[i] = &exams.RunReport{Report: report, Protocol: jobs[i].protocol, Duration: time.Since(start)}
+			}
+		}()
+	}
+
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+
+	wg.Wait()
+
+	return reports
+}
+
+func runJob(ctx context.Context, j job) exams.Report {
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+
+	report, err := exams.ExaminateEnv(ctx, j.exam, j.env)
+	if err != nil {
+		return interruptedReport(j, err)
+	}
+
+	return report
+}
+
+// Parses the `timeout` field of an exam. Exams without it use the default timeout
+func parseTimeout(exam config.Exam, defaultTimeout time.Duration) (time.Duration, error) {
+	if exam.Timeout == "" {
+		return defaultTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(exam.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, &exams.FieldValueError{Field: "timeout", Exam: exam.Type, Value: exam.Timeout, Message: "expected a positive duration like 10s"}
+	}
+
+	return timeout, nil
 }
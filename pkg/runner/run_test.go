@@ -0,0 +1,222 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/exams/env"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunProtocolsInOrder(t *testing.T) {
+	cfg := &config.Medik{
+		Exams: []config.Exam{vars("env.is-set", "MEDIK_RUNNER_TOP")},
+		Protocols: map[string]config.Protocol{
+			"release": {Exams: []config.Exam{vars("env.int", "MEDIK_RUNNER_INT")}},
+			"test":    {Exams: []config.Exam{vars("env.not-empty", "MEDIK_RUNNER_TOP")}},
+			"unused":  {Exams: []config.Exam{vars("env.is-set", "MEDIK_RUNNER_UNUSED")}},
+		},
+	}
+
+	t.Setenv("MEDIK_RUNNER_TOP", "value")
+	t.Setenv("MEDIK_RUNNER_INT", "10")
+
+	success, reports, err := Run(cfg, []string{"test", "release", "test", "missing"})
+	assert.Nil(t, err)
+	assert.Equal(t, medik.OK, success)
+	assert.Len(t, reports, 3)
+
+	assert.Equal(t, "", reports[0].Data().Protocol)
+	assert.Equal(t, "test", reports[1].Data().Protocol)
+	assert.Equal(t, "env.not-empty", reports[1].Data().Exam)
+	assert.Equal(t, "release", reports[2].Data().Protocol)
+
+	// The config must not be changed by a run
+	assert.Len(t, cfg.Protocols, 3)
+}
+
+func TestRunUnknownExam(t *testing.T) {
+	cfg := &config.Medik{Exams: []config.Exam{vars("env.unknown", "FOO")}}
+
+	_, _, err := Run(cfg, nil)
+
+	var unknown *UnknownExamError
+	assert.ErrorAs(t, err, &unknown)
+}
+
+func TestRunParallelKeepsOrder(t *testing.T) {
+	cfg := &config.Medik{Jobs: 4}
+	commands := []string{}
+
+	// Earlier exams take longer, so they finish after the later ones
+	for i := range 8 {
+		commands = append(commands, fmt.Sprintf("sleep 0.%v; exit %v", 8-i, i%2))
+		cfg.Exams = append(cfg.Exams, command(commands[i]))
+	}
+
+	success, reports, err := Run(cfg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, medik.ERROR, success)
+	assert.Len(t, reports, 8)
+
+	for i, report := range reports {
+		data := report.Data()
+		assert.Equal(t, commands[i], data.Statuses[0].Key)
+		assert.Equal(t, i%2*medik.ERROR, data.Level)
+		assert.Greater(t, data.Duration, time.Duration(0))
+	}
+}
+
+// A group of exams that only finish once `size` of them are running at the same time
+type barrier struct {
+	mu      sync.Mutex
+	size    int
+	running int
+	release chan struct{}
+}
+
+type barrierExam struct {
+	exams.Exam
+	barrier *barrier
+}
+
+// Reports an error if the other exams of the barrier don't start running in a reasonable time
+func (e *barrierExam) Examinate() exams.Report {
+	b := e.barrier
+
+	b.mu.Lock()
+	b.running++
+	if b.running == b.size {
+		close(b.release)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-b.release:
+		return &env.EnvReport{Type: "test.barrier", Lvl: medik.OK}
+	case <-time.After(10 * time.Second):
+		return &env.EnvReport{Type: "test.barrier", Lvl: medik.ERROR}
+	}
+}
+
+func TestRunJobsConcurrently(t *testing.T) {
+	b := &barrier{size: 4, release: make(chan struct{})}
+	jobs := make([]job, 8)
+
+	for i := range jobs {
+		jobs[i] = job{exam: &barrierExam{barrier: b}, env: environment.Process}
+	}
+
+	// Each exam waits until 4 of them are running, which only happens if they run in parallel
+	for _, report := range runJobs(context.Background(), jobs, b.size) {
+		assert.Equal(t, medik.OK, report.Level())
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	cfg := &config.Medik{
+		Timeout: "5s",
+		Exams:   []config.Exam{command("sleep 2"), command("true")},
+	}
+	cfg.Exams[0].Level = "warning"
+	cfg.Exams[0].Timeout = "100ms"
+
+	success, reports, err := Run(cfg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, medik.WARNING, success)
+	assert.IsType(t, &InterruptedReport{}, reports[0].(*exams.RunReport).Report)
+	assert.Equal(t, "timed out after 100ms", reports[0].Data().Statuses[0].Message)
+	assert.Equal(t, medik.OK, reports[1].Level())
+}
+
+func TestRunInvalidTimeout(t *testing.T) {
+	cfg := &config.Medik{Exams: []config.Exam{vars("env.is-set", "FOO")}}
+	cfg.Exams[0].Timeout = "soon"
+	_, _, err := Run(cfg, nil)
+
+	var invalid *exams.FieldValueError
+	assert.ErrorAs(t, err, &invalid)
+
+	cfg = &config.Medik{Timeout: "-1s"}
+	_, _, err = Run(cfg, nil)
+	assert.NotNil(t, err)
+}
+
+func TestRunCancelled(t *testing.T) {
+	cfg := &config.Medik{Exams: []config.Exam{vars("env.is-set", "MEDIK_RUNNER_TOP")}}
+	cfg.Exams[0].Level = "warning"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	success, reports, err := RunContext(ctx, cfg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, medik.ERROR, success)
+	assert.Contains(t, reports[0].Data().Statuses[0].Message, "cancelled")
+}
+
+// Creates an exam of type `ty` checking the env vars `names`
+func vars(ty string, names ...string) config.Exam {
+	return config.NewExam(ty, map[string]interface{}{"vars": names})
+}
+
+// Creates a cmd.custom exam running `run`
+func command(run string) config.Exam {
+	return config.NewExam("cmd.custom", map[string]interface{}{"cmd": map[string]interface{}{"run": run}})
+}
+
+func TestRunPlugins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "medik-plugin")
+	err := os.WriteFile(path, []byte("#!/bin/sh\necho '{\"statuses\": [{\"key\": \"vpn0\", \"message\": \"is down\", \"level\": \"warning\"}]}'\n"), 0o755)
+	assert.Nil(t, err)
+
+	cfg, err := config.Parse(fmt.Sprintf(`
+plugins:
+  acme: %v
+exams:
+  - exam: acme.vpn-connected
+    interfaces: [vpn0]
+`, path))
+	assert.Nil(t, err)
+
+	success, reports, err := Run(cfg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, medik.WARNING, success)
+	assert.Equal(t, "acme.vpn-connected", reports[0].Data().Exam)
+	assert.Equal(t, "vpn0", reports[0].Data().Statuses[0].Key)
+}
+
+func TestPluginPath(t *testing.T) {
+	assert.Equal(t, "/etc/medik/scripts/acme", pluginPath("/etc/medik", "./scripts/acme"))
+	assert.Equal(t, "/opt/acme", pluginPath("/etc/medik", "/opt/acme"))
+	assert.Equal(t, "medik-acme", pluginPath("/etc/medik", "medik-acme"))
+	assert.Equal(t, "./scripts/acme", pluginPath("", "./scripts/acme"))
+}
+
+func TestRunInvalidPlugins(t *testing.T) {
+	cfg, err := config.Parse(`
+plugins:
+  env: ./env-plugin
+  a.b: ./plugin
+  empty: ""
+`)
+	assert.Nil(t, err)
+
+	problems := Validate(cfg)
+	assert.Len(t, problems.Errors, 3)
+
+	var pluginErr *PluginError
+	assert.ErrorAs(t, problems, &pluginErr)
+	assert.Equal(t, "4:8: invalid plugin 'a.b': category names can't be empty or have dots or spaces", problems.Errors[0].Error())
+	assert.Equal(t, "5:10: invalid plugin 'empty': missing the path to its executable", problems.Errors[1].Error())
+	assert.Equal(t, "3:8: invalid plugin 'env': it's a built-in category", problems.Errors[2].Error())
+}
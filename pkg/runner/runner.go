@@ -0,0 +1,205 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/format"
+	"github.com/OJarrisonn/medik/pkg/medik"
+)
+
+// Runs medik from Go code, the same way the CLI does, but without any package-level state
+// and without exiting the process. Every setting of the CLI has a matching field
+// The process environment is never changed, env files are only visible to the exams
+// The zero value runs the config at medik.DefaultConfigFile showing every status and writing nothing
+type Runner struct {
+	// The config to run. When nil, it's read from ConfigFile
+	Config *config.Medik
+	// Path to the config file, medik.DefaultConfigFile if empty
+	ConfigFile string
+	// The variables examined by the exams, below the env files. When nil, it's the process environment
+	Env environment.Source
+	// Paths to env files whose variables are examined, on top of the env files of the config. Later files win
+	EnvFiles []string
+	// Examine only the variables of the env files, ignoring the process environment
+	EnvOnly bool
+	// Protocols to run besides the top-level exams
+	Protocols []string
+	// Reports and statuses below this level are left out of the text output
+	Verbosity int
+	NoColor   bool
+	// Output format, one of medik.OutputText (the default), medik.OutputJSON or medik.OutputJUnit
+	Output string
+	// Report warnings as skipped test cases in the JUnit output
+	JUnitSkipWarnings bool
+	// Overrides `jobs` of the config when greater than zero
+	Jobs int
+	// Overrides `timeout` of the config when greater than zero
+	Timeout time.Duration
+	// Accept unknown fields in the config, overriding `strict`
+	NoStrict bool
+	// Show the values of secret variables in reports, instead of masking them
+	ShowSecrets bool
+	// Where the output is written. When nil, nothing is written and only the Result is returned
+	Out io.Writer
+}
+
+// The outcome of a run: its overall level and the report of each exam, in the order they ran
+type Result struct {
+	Level   int
+	Reports []exams.Report
+}
+
+// Whether the environment is healthy, ie. no exam reported an error
+func (r *Result) Healthy() bool {
+	return r.Level < medik.ERROR
+}
+
+// Creates a Runner with the same defaults as the CLI
+func New() *Runner {
+	return &Runner{
+		ConfigFile: medik.DefaultConfigFile,
+		Verbosity:  medik.DefaultVerbosity,
+		NoColor:    medik.DefaultNoColor,
+		Output:     medik.DefaultOutput,
+		Jobs:       medik.DefaultJobs,
+	}
+}
+
+// Loads the config and the env file, runs the exams and writes the reports to Out
+// An invalid config returns a *ConfigError and nothing is run. The Result is returned even
+// if writing the output fails
+func (r *Runner) Run(ctx context.Context) (*Result, error) {
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	env, overrides, err := r.LoadEnv()
+	if err != nil {
+		return nil, fmt.Errorf("loading env: %w", err)
+	}
+
+	if r.Jobs > 0 {
+		cfg.Jobs = r.Jobs
+	}
+
+	if r.Timeout > 0 {
+		cfg.Timeout = r.Timeout.String()
+	}
+
+	level, reports, err := run(ctx, cfg, r.Protocols, env, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Level: level, Reports: reports}
+
+	if err := r.write(result); err != nil {
+		return result, fmt.Errorf("writing output: %w", err)
+	}
+
+	return result, nil
+}
+
+// Loads the config and reports all its problems without running any exam, see Validate
+func (r *Runner) Validate() (*ConfigError, error) {
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return Validate(cfg), nil
+}
+
+// Returns a copy of Config, or the config read from ConfigFile, with the overrides of the Runner applied
+func (r *Runner) LoadConfig() (*config.Medik, error) {
+	var cfg config.Medik
+
+	if r.Config != nil {
+		cfg = *r.Config
+	} else {
+		path := r.ConfigFile
+		if path == "" {
+			path = medik.DefaultConfigFile
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := config.Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		cfg = *parsed
+
+		// Absolute, so the paths resolved against it never look like bare names
+		cfg.Dir, err = filepath.Abs(filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.NoStrict {
+		strict := false
+		cfg.Strict = &strict
+	}
+
+	cfg.ShowSecrets = r.ShowSecrets
+
+	return &cfg, nil
+}
+
+// Writes the result to Out using the selected output format
+func (r *Runner) write(result *Result) error {
+	if r.Out == nil {
+		return nil
+	}
+
+	switch r.Output {
+	case medik.OutputText, "":
+		return format.Text(r.Out, result.Level, result.Reports, r.Verbosity, r.NoColor)
+	case medik.OutputJSON:
+		return format.JSON(r.Out, result.Level, result.Reports)
+	case medik.OutputJUnit:
+		return format.JUnit(r.Out, result.Reports, r.JUnitSkipWarnings)
+	default:
+		return fmt.Errorf("unknown output format: %v", r.Output)
+	}
+}
+
+// Returns the environment the config is examined against, Env or the process environment (nothing if
+// EnvOnly is set), and the env files of EnvFiles that go on top of it and of the env files of the config
+func (r *Runner) LoadEnv() (environment.Source, environment.Layered, error) {
+	var env environment.Source = environment.Process
+
+	switch {
+	case r.Env != nil:
+		env = r.Env
+	case r.EnvOnly:
+		env = environment.Map{}
+	}
+
+	overrides := environment.Layered{}
+
+	for _, path := range r.EnvFiles {
+		file, err := loadEnvFile("", path, append(environment.Layered{env}, overrides...))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		overrides = append(overrides, file)
+	}
+
+	return env, overrides, nil
+}
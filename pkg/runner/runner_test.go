@@ -0,0 +1,356 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEnvFileNotSet(t *testing.T) {
+	env, overrides, err := (&Runner{}).LoadEnv()
+	if err != nil {
+		t.Errorf("LoadEnv() not accepted empty filename: %v", err)
+	}
+
+	if env != environment.Process || len(overrides) != 0 {
+		t.Errorf("LoadEnv() didn't use the process environment: %v %v", env, overrides)
+	}
+}
+
+func TestLoadEnvFileInexistent(t *testing.T) {
+	_, _, err := (&Runner{EnvFiles: []string{"/this/file/is/inexistent.env"}}).LoadEnv()
+	if err == nil {
+		t.Error("LoadEnv() accepted an non existent file")
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	t.Setenv("MEDIK_RUNNER_PROCESS", "process")
+
+	env, overrides, err := (&Runner{EnvFiles: []string{"../../samples/root_test.env"}}).LoadEnv()
+	if err != nil {
+		t.Errorf("LoadEnv() failed: %v", err)
+	}
+
+	layered := append(environment.Layered{env}, overrides...)
+
+	if root, ok := layered.LookupEnv("ROOT"); !ok || root != "test" {
+		t.Errorf("LoadEnv() failed: ROOT = '%v'", root)
+	}
+
+	if test, ok := layered.LookupEnv("TEST"); !ok || test != "root" {
+		t.Errorf("LoadEnv() failed: TEST = '%v'", test)
+	}
+
+	if value, _ := layered.LookupEnv("MEDIK_RUNNER_PROCESS"); value != "process" {
+		t.Errorf("LoadEnv() failed: process variables aren't visible")
+	}
+
+	if origin := environment.Origin(layered, "ROOT"); origin != "../../samples/root_test.env" {
+		t.Errorf("LoadEnv() failed: ROOT comes from '%v'", origin)
+	}
+
+	// The process environment is left untouched
+	if _, ok := os.LookupEnv("ROOT"); ok {
+		t.Error("LoadEnv() failed: ROOT was set in the process environment")
+	}
+}
+
+func TestLoadEnvFileOnly(t *testing.T) {
+	t.Setenv("MEDIK_RUNNER_PROCESS", "process")
+
+	env, overrides, err := (&Runner{EnvFiles: []string{"../../samples/root_test.env"}, EnvOnly: true}).LoadEnv()
+	if err != nil {
+		t.Errorf("LoadEnv() failed: %v", err)
+	}
+
+	layered := append(environment.Layered{env}, overrides...)
+
+	if _, ok := layered.LookupEnv("MEDIK_RUNNER_PROCESS"); ok {
+		t.Error("LoadEnv() failed: process variables are visible")
+	}
+
+	if root, _ := layered.LookupEnv("ROOT"); root != "test" {
+		t.Errorf("LoadEnv() failed: ROOT = '%v'", root)
+	}
+}
+
+// Writes an env file with `content` in a temporary directory and returns its path
+func envFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadEnvFileExpansion(t *testing.T) {
+	t.Setenv("MEDIK_RUNNER_PROCESS", "process")
+
+	path := envFile(t, ".env", "FOO=${MEDIK_RUNNER_PROCESS:-file}\n")
+	local := envFile(t, ".env.local", "BAR=${FOO}-local\n")
+
+	_, overrides, err := (&Runner{EnvFiles: []string{path, local}}).LoadEnv()
+	if value, _ := overrides.LookupEnv("BAR"); err != nil || value != "process-local" {
+		t.Errorf("LoadEnv() failed: BAR = '%v' %v", value, err)
+	}
+
+	// Isolated env files don't see the process environment
+	_, overrides, err = (&Runner{EnvFiles: []string{path, local}, EnvOnly: true}).LoadEnv()
+	if value, _ := overrides.LookupEnv("BAR"); err != nil || value != "file-local" {
+		t.Errorf("LoadEnv() failed: BAR = '%v' %v", value, err)
+	}
+}
+
+func TestLoadEnvFileInvalid(t *testing.T) {
+	path := envFile(t, ".env", "FOO=bar\nBAR\n")
+
+	_, _, err := (&Runner{EnvFiles: []string{path}}).LoadEnv()
+	if err == nil || err.Error() != "invalid env file '"+path+"': line 2: missing '=' after variable 'BAR'" {
+		t.Errorf("LoadEnv() failed: %v", err)
+	}
+}
+
+func TestLoadConfigFileInexistent(t *testing.T) {
+	r := &Runner{ConfigFile: "/this/file/is/inexistent.yaml"}
+
+	_, err := r.LoadConfig()
+	if err == nil {
+		t.Error("LoadConfig() accepted an non existent file")
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	r := &Runner{ConfigFile: "../../samples/root_test.yaml"}
+
+	config, err := r.LoadConfig()
+	if err != nil {
+		t.Errorf("LoadConfig() failed: %v", err)
+	}
+
+	if len(config.Exams) != 1 {
+		t.Errorf("LoadConfig() failed: %v", config.Exams)
+	}
+}
+
+func TestRunnerPluginNextToConfig(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "scripts"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "scripts", "acme"), []byte("#!/bin/sh\necho '{\"statuses\": []}'\n"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "medik.yaml"), []byte("plugins:\n  acme: ./scripts/acme\nexams:\n  - exam: acme.vpn\n"), 0o644))
+
+	// The plugin is found next to the config, not in the working directory
+	result, err := (&Runner{ConfigFile: filepath.Join(dir, "medik.yaml")}).Run(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, result.Healthy())
+}
+
+func TestRunnerPathsNextToConfig(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "work"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("MEDIK_RUNNER_PORT=8080\nMEDIK_RUNNER_JSON={\"a\": 1}\n"), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, ".env.example"), []byte("MEDIK_RUNNER_PORT=\n"), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"type": "object"}`), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "medik.yaml"), []byte(`env-files: [.env]
+exams:
+  - exam: env.matches-template
+    template: .env.example
+  - exam: env.json
+    vars: [MEDIK_RUNNER_JSON]
+    schema-file: schema.json
+  - exam: cmd.custom
+    cmd:
+      run: test -d ../work
+      dir: work
+`), 0o644))
+
+	// The files are found next to the config, not in the working directory
+	result, err := (&Runner{ConfigFile: filepath.Join(dir, "medik.yaml")}).Run(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, result.Healthy())
+}
+
+func TestRunnerRun(t *testing.T) {
+	t.Setenv("MEDIK_RUNNER_FOO", "bar")
+
+	cfg := &config.Medik{Exams: []config.Exam{vars("env.is-set", "MEDIK_RUNNER_FOO"), vars("env.is-set", "MEDIK_RUNNER_MISSING")}}
+	out := &bytes.Buffer{}
+	r := &Runner{Config: cfg, Verbosity: medik.OK, NoColor: true, Out: out}
+
+	result, err := r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, medik.ERROR, result.Level)
+	assert.False(t, result.Healthy())
+	assert.Len(t, result.Reports, 2)
+
+	assert.Equal(t, " OK  env.is-set\n\t MEDIK_RUNNER_FOO  is valid\n\n ERROR  env.is-set\n\t MEDIK_RUNNER_MISSING  is not set\n\n Environment Unhealthy \n", out.String())
+
+	// Statuses below the verbosity are left out
+	out.Reset()
+	r.Verbosity = medik.ERROR
+
+	_, err = r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.NotContains(t, out.String(), "MEDIK_RUNNER_FOO")
+	assert.Contains(t, out.String(), "MEDIK_RUNNER_MISSING")
+}
+
+func TestRunnerOutput(t *testing.T) {
+	cfg := &config.Medik{Exams: []config.Exam{vars("env.is-set", "HOME")}}
+	out := &bytes.Buffer{}
+	r := &Runner{Config: cfg, Output: medik.OutputJSON, Out: out}
+
+	_, err := r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), `"exam": "env.is-set"`)
+
+	// The result is returned even if the output can't be written
+	r.Output = "yaml"
+
+	result, err := r.Run(context.Background())
+	assert.NotNil(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestRunnerInvalidConfig(t *testing.T) {
+	cfg := &config.Medik{Exams: []config.Exam{config.NewExam("env.is-set", map[string]interface{}{"vars": []string{"FOO"}, "regx": "x"})}}
+	r := &Runner{Config: cfg}
+
+	result, err := r.Run(context.Background())
+	assert.Nil(t, result)
+
+	var problems *ConfigError
+	assert.True(t, errors.As(err, &problems))
+
+	// NoStrict accepts unknown fields, without changing the given config
+	r.NoStrict = true
+
+	result, err = r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, result.Reports, 1)
+	assert.Equal(t, "env.is-set", result.Reports[0].Data().Exam)
+	assert.Nil(t, cfg.Strict)
+
+	validation, err := r.Validate()
+	assert.Nil(t, err)
+	assert.Nil(t, validation)
+}
+
+func TestRunnerEnv(t *testing.T) {
+	t.Setenv("MEDIK_RUNNER_FOO", "process")
+
+	cfg := &config.Medik{Exams: []config.Exam{
+		vars("env.is-set", "MEDIK_RUNNER_FOO"),
+		config.NewExam("env.regex", map[string]interface{}{"vars": []string{"MEDIK_RUNNER_BAR"}, "regex": "^file$"}),
+	}}
+	r := &Runner{Config: cfg, Env: environment.Map{"MEDIK_RUNNER_BAR": "file"}}
+
+	// Only the given environment is examined
+	result, err := r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, medik.ERROR, result.Reports[0].Level())
+	assert.Equal(t, medik.OK, result.Reports[1].Level())
+
+	r.Env = environment.Layered{environment.Process, r.Env}
+
+	result, err = r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, result.Healthy())
+
+	_, ok := os.LookupEnv("MEDIK_RUNNER_BAR")
+	assert.False(t, ok)
+}
+
+func TestRunnerEnvFiles(t *testing.T) {
+	base := envFile(t, ".env", "MEDIK_A=base\nMEDIK_B=base\nMEDIK_C=base\n")
+	stage := envFile(t, ".env.stage", "MEDIK_B=stage\n")
+	local := envFile(t, ".env.local", "MEDIK_C=local\n")
+
+	cfg := &config.Medik{
+		EnvFiles: []string{base},
+		Exams:    []config.Exam{config.NewExam("env.regex", map[string]interface{}{"vars": []string{"MEDIK_A", "MEDIK_B", "MEDIK_C"}, "regex": "^base|local$"})},
+		Protocols: map[string]config.Protocol{
+			"stage": {EnvFiles: []string{stage}, Exams: []config.Exam{config.NewExam("env.regex", map[string]interface{}{"vars": []string{"MEDIK_B", "MEDIK_C"}, "regex": "^stage|local$"})}},
+		},
+	}
+
+	r := &Runner{Config: cfg, EnvFiles: []string{local}, EnvOnly: true, Protocols: []string{"stage"}}
+
+	result, err := r.Run(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, result.Healthy())
+
+	// Protocol env files only apply to their exams, and the origin of each value is reported
+	top, protocol := result.Reports[0].Data(), result.Reports[1].Data()
+	assert.Equal(t, []string{base, base, local}, []string{top.Statuses[0].Origin, top.Statuses[1].Origin, top.Statuses[2].Origin})
+	assert.Equal(t, []string{stage, local}, []string{protocol.Statuses[0].Origin, protocol.Statuses[1].Origin})
+
+	_, _, body := result.Reports[1].Format(medik.OK, true)
+	assert.Contains(t, body, "MEDIK_B  is valid (from "+stage+")")
+}
+
+func TestValidateEnvFiles(t *testing.T) {
+	invalid := envFile(t, ".env", "FOO='bar\n")
+
+	cfg, err := config.Parse(`
+env-files: [` + invalid + `]
+protocols:
+  test:
+    env-files:
+      - .env.missing
+exams:
+  - exam: env.is-set
+    vars: [FOO]
+`)
+	assert.Nil(t, err)
+
+	problems := Validate(cfg)
+	assert.Len(t, problems.Errors, 2)
+	assert.Equal(t, "2:13: invalid env file '"+invalid+"': line 1: unterminated single quoted value", problems.Errors[0].Error())
+	assert.Equal(t, "6:9: protocols.test: invalid env file '.env.missing': open .env.missing: no such file or directory", problems.Errors[1].Error())
+}
+
+func TestRunnerSecrets(t *testing.T) {
+	cfg := &config.Medik{Exams: []config.Exam{
+		config.NewExam("env.int", map[string]interface{}{"vars": []string{"MEDIK_API_KEY", "MEDIK_PORT"}}),
+	}}
+	r := &Runner{Config: cfg, Env: environment.Map{"MEDIK_API_KEY": "hunter2", "MEDIK_PORT": "http"}}
+
+	// The default patterns hide `*_KEY` variables
+	result, err := r.Run(context.Background())
+	assert.Nil(t, err)
+	_, _, body := result.Reports[0].Format(medik.OK, true)
+	assert.NotContains(t, body, "hunter2")
+	assert.Contains(t, body, "http")
+
+	cfg.Secrets = []string{"*_PORT"}
+
+	result, err = r.Run(context.Background())
+	assert.Nil(t, err)
+	_, _, body = result.Reports[0].Format(medik.OK, true)
+	assert.Contains(t, body, "hunter2")
+	assert.NotContains(t, body, "http")
+
+	r.ShowSecrets = true
+
+	result, err = r.Run(context.Background())
+	assert.Nil(t, err)
+	_, _, body = result.Reports[0].Format(medik.OK, true)
+	assert.Contains(t, body, "http")
+
+	cfg.Secrets = []string{"[KEY"}
+
+	validation, err := r.Validate()
+	assert.Nil(t, err)
+	assert.Len(t, validation.Errors, 1)
+	assert.Equal(t, "invalid secret pattern '[KEY': syntax error in pattern", validation.Errors[0].Error())
+}
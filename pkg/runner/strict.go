@@ -0,0 +1,171 @@
+package runner
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"gopkg.in/yaml.v3"
+)
+
+// Checks the keys at the top-level of the config, appending an error for each unknown one
+func checkTopLevelKeys(cfg *config.Medik, problems *ConfigError) {
+	for _, key := range config.UnknownKeys(cfg.Node, reflect.TypeOf(config.Medik{})) {
+		problems.Errors = append(problems.Errors, &ExamError{Index: -1, Line: key.Node.Line, Column: key.Node.Column, Err: unknownFieldError(key, "", key.Known)})
+	}
+}
+
+// Checks the keys of a protocol, appending an error for each unknown one
+func checkProtocolKeys(cfg *config.Medik, name string, problems *ConfigError) {
+	for _, key := range config.UnknownKeys(cfg.ProtocolNode(name), reflect.TypeOf(config.Protocol{})) {
+		problems.Errors = append(problems.Errors, &ExamError{Protocol: name, Index: -1, Line: key.Node.Line, Column: key.Node.Column, Err: unknownFieldError(key, "", key.Known)})
+	}
+}
+
+// Checks the keys of an exam against the fields common to every exam and the ones registered for its type,
+// whether or not it could be parsed. `known` are the paths of the fields of every registered exam
+// Appends an error for each key that no exam accepts, and a warning for each key accepted by other exams only
+// Exam types without registered fields (see exams.RegisterFields) aren't checked
+// Returns false if any unknown key was found
+func checkExamKeys(cfg *config.Exam, protocol string, index int, known []string, problems *ConfigError) bool {
+	fields, ok := exams.ExamFields(cfg.Type)
+	if !ok {
+		return true
+	}
+
+	common := config.YAMLKeys(reflect.TypeOf(config.Exam{}))
+	ok = true
+
+	for _, key := range config.UnknownKeys(cfg.Node, reflect.TypeOf(fields)) {
+		if slices.Contains(common, key.Path) {
+			continue
+		}
+
+		if !strings.Contains(key.Path, ".") {
+			key.Known = slices.Concat(key.Known, common)
+		}
+
+		if slices.Contains(known, key.Path) {
+			err := &exams.UnusedFieldError{Field: key.Path, Exam: cfg.Type, Suggestion: suggest(key.Path, key.Known)}
+			problems.Warnings = append(problems.Warnings, &ExamError{Protocol: protocol, Index: index, Line: key.Node.Line, Column: key.Node.Column, Err: err})
+			continue
+		}
+
+		ok = false
+		candidates := slices.Concat(key.Known, siblings(key.Path, known))
+		problems.Errors = append(problems.Errors, &ExamError{Protocol: protocol, Index: index, Line: key.Node.Line, Column: key.Node.Column, Err: unknownFieldError(key, cfg.Type, candidates)})
+	}
+
+	return ok
+}
+
+// Returns the paths of the fields of every exam registered with exams.RegisterFields, without duplicates
+func registeredExamPaths() []string {
+	paths := []string{}
+
+	for _, fields := range exams.AllExamFields() {
+		for _, path := range config.YAMLPaths(reflect.TypeOf(fields)) {
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	return paths
+}
+
+// Returns the paths in `paths` at the same nesting level as `path`, like `cmd.run` for `cmd.exit-cod`
+func siblings(path string, paths []string) []string {
+	prefix := ""
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		prefix = path[:i+1]
+	}
+
+	result := []string{}
+
+	for _, p := range paths {
+		if rest, ok := strings.CutPrefix(p, prefix); ok && !strings.Contains(rest, ".") {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func unknownFieldError(key config.UnknownKey, exam string, candidates []string) *exams.UnknownFieldError {
+	return &exams.UnknownFieldError{Field: key.Path, Exam: exam, Suggestion: suggest(key.Path, candidates)}
+}
+
+// Returns the line and column of a key of the config, following the `path` of nested keys
+// Returns 0, 0 if unknown
+func configPosition(cfg *config.Medik, path ...string) (int, int) {
+	node := cfg.Node
+	for _, key := range path {
+		node = mappingValue(node, key)
+	}
+
+	if node == nil {
+		return 0, 0
+	}
+
+	return node.Line, node.Column
+}
+
+// Returns the value of a key in a mapping node, or nil if it's not there
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	keys := config.MappingKeys(node)
+
+	for i, k := range keys {
+		if k.Value == key {
+			return node.Content[2*i+1]
+		}
+	}
+
+	return nil
+}
+
+// Returns the candidate closest to `field`, or an empty string if none is close enough to be a typo
+func suggest(field string, candidates []string) string {
+	best, bestDistance := "", max(1, len(field)/3)+1
+
+	for _, candidate := range candidates {
+		if d := distance(field, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best
+}
+
+// Computes the edit distance between two strings, counting insertions, deletions, substitutions
+// and transpositions of adjacent characters as a single edit each
+func distance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/stretchr/testify/assert"
+)
+
+const typos = `
+exmas: []
+exams:
+  - exam: env.is-set
+    vars: [FOO]
+    regx: "a"
+  - exam: cmd.custom
+    cmd:
+      run: "true"
+      exit-cod: 0
+  - exam: env.is-set
+    vars: [FOO]
+    regex: "a"
+protocols:
+  release:
+    exam: []
+`
+
+func TestStrictUnknownFields(t *testing.T) {
+	cfg, err := config.Parse(typos)
+	assert.Nil(t, err)
+
+	problems := Validate(cfg)
+	assert.NotNil(t, problems)
+	assert.Len(t, problems.Errors, 4)
+
+	assert.Equal(t, "2:1: unknown field `exmas`, did you mean `exams`?", problems.Errors[0].Error())
+	assert.Equal(t, "6:5: exams[0]: unknown field `regx` in exam env.is-set, did you mean `regex`?", problems.Errors[1].Error())
+	assert.Equal(t, "10:7: exams[1]: unknown field `cmd.exit-cod` in exam cmd.custom, did you mean `cmd.exit-code`?", problems.Errors[2].Error())
+	assert.Equal(t, "16:5: protocols.release: unknown field `exam`, did you mean `exams`?", problems.Errors[3].Error())
+
+	var unknown *exams.UnknownFieldError
+	assert.ErrorAs(t, problems, &unknown)
+}
+
+func TestStrictUnusedFields(t *testing.T) {
+	cfg, err := config.Parse(typos)
+	assert.Nil(t, err)
+
+	problems := Validate(cfg)
+	assert.Len(t, problems.Warnings, 1)
+	assert.Equal(t, "13:5: exams[2]: field `regex` is not used by exam env.is-set", problems.Warnings[0].Error())
+}
+
+func TestStrictUnknownFieldOfInvalidExam(t *testing.T) {
+	cfg, err := config.Parse(`
+exams:
+  - exam: env.int
+    var: [HOME]
+`)
+	assert.Nil(t, err)
+
+	problems := Validate(cfg)
+	assert.Len(t, problems.Errors, 2)
+	assert.Equal(t, "4:5: exams[0]: unknown field `var` in exam env.int, did you mean `vars`?", problems.Errors[1].Error())
+}
+
+func TestStrictDisabled(t *testing.T) {
+	cfg, err := config.Parse("strict: false\n" + typos)
+	assert.Nil(t, err)
+	assert.Nil(t, Validate(cfg))
+}
+
+func TestRunReportsUnusedFields(t *testing.T) {
+	cfg, err := config.Parse(`
+exams:
+  - exam: env.is-set
+    vars: [PATH]
+    paths: [.]
+`)
+	assert.Nil(t, err)
+
+	success, reports, err := Run(cfg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, medik.WARNING, success)
+	assert.Len(t, reports, 2)
+
+	data := reports[0].Data()
+	assert.Equal(t, "config", data.Exam)
+	assert.Equal(t, "5:5: exams[0]", data.Statuses[0].Key)
+}
+
+func TestSuggest(t *testing.T) {
+	assert.Equal(t, "version-regex", suggest("version_regex", []string{"version-args", "version-regex"}))
+	assert.Equal(t, "", suggest("foo", []string{"version-args", "constraint"}))
+}
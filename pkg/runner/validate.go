@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/environment"
+	"github.com/OJarrisonn/medik/pkg/exams"
+)
+
+// A problem found in the config
+// Index is the position of the exam in its list, or -1 if the problem isn't inside an exam
+// Protocol is the protocol where the problem is, it's empty for the top-level of the config
+// Line and Column locate the problem in the YAML file, they're 0 if unknown
+type ExamError struct {
+	Protocol string
+	Index    int
+	Line,
+	Column int
+	Err error
+}
+
+// Returns where the problem is, like `12:9: protocols.release.exams[0]`
+func (e *ExamError) Location() string {
+	where := ""
+
+	switch {
+	case e.Protocol != "" && e.Index >= 0:
+		where = fmt.Sprintf("protocols.%v.exams[%d]", e.Protocol, e.Index)
+	case e.Protocol != "":
+		where = "protocols." + e.Protocol
+	case e.Index >= 0:
+		where = fmt.Sprintf("exams[%d]", e.Index)
+	}
+
+	if e.Line > 0 && where != "" {
+		return fmt.Sprintf("%d:%d: %v", e.Line, e.Column, where)
+	}
+
+	if e.Line > 0 {
+		return fmt.Sprintf("%d:%d", e.Line, e.Column)
+	}
+
+	return where
+}
+
+func (e *ExamError) Error() string {
+	if location := e.Location(); location != "" {
+		return location + ": " + e.Err.Error()
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExamError) Unwrap() error {
+	return e.Err
+}
+
+// All the problems found in a config
+// Errors make the config invalid, while Warnings are only reported
+type ConfigError struct {
+	Errors   []*ExamError
+	Warnings []*ExamError
+}
+
+func (e *ConfigError) Error() string {
+	lines := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		lines[i] = err.Error()
+	}
+
+	return fmt.Sprintf("found %d problem(s) in the config:\n%v", len(e.Errors), strings.Join(lines, "\n"))
+}
+
+func (e *ConfigError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Parses every exam in the config, including the ones of every protocol, without running them
+// Returns all the problems found, or nil if there's none. The config is valid if the result has no Errors
+func Validate(config *config.Medik) *ConfigError {
+	names := make([]string, 0, len(config.Protocols))
+	for name := range config.Protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, problems := parseConfig(config, names, environment.Process, nil)
+
+	if len(problems.Errors) == 0 && len(problems.Warnings) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// Creates an ExamError locating `err` in the YAML file
+// Errors about the value of a field point to that field, the others point to the exam
+func newExamError(exam *config.Exam, protocol string, index int, err error) *ExamError {
+	line, column := exam.Position()
+
+	var invalid *exams.FieldValueError
+	if errors.As(err, &invalid) {
+		line, column = exam.FieldPosition(invalid.Field)
+	}
+
+	return &ExamError{Protocol: protocol, Index: index, Line: line, Column: column, Err: err}
+}
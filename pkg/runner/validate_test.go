@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OJarrisonn/medik/pkg/config"
+	"github.com/OJarrisonn/medik/pkg/exams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCollectsAllErrors(t *testing.T) {
+	cfg, err := config.Parse(`
+exams:
+  - exam: env.is-set
+    vars: [FOO]
+  - exam: env.nope
+  - exam: env.regex
+    vars: [FOO]
+    regex: "["
+protocols:
+  release:
+    exams:
+      - exam: env.int
+  test:
+    exams:
+      - exam: env.is-set
+        vars: [FOO]
+        timeout: soon
+`)
+	assert.Nil(t, err)
+
+	configErr := Validate(cfg)
+	assert.NotNil(t, configErr)
+	assert.Len(t, configErr.Errors, 4)
+
+	assert.Equal(t, &ExamError{Index: 1, Line: 5, Column: 5, Err: &UnknownExamError{ExamType: "env.nope"}}, configErr.Errors[0])
+
+	// Field errors point to the field
+	assert.Equal(t, 8, configErr.Errors[1].Line)
+	assert.Equal(t, 12, configErr.Errors[1].Column)
+
+	// Protocols are validated in alphabetical order
+	assert.Equal(t, "release", configErr.Errors[2].Protocol)
+	assert.Equal(t, "test", configErr.Errors[3].Protocol)
+	assert.Equal(t, "17:18: protocols.test.exams[0]: invalid value 'soon' for field `timeout` in exam env.is-set: expected a positive duration like 10s", configErr.Errors[3].Error())
+
+	var invalid *exams.FieldValueError
+	assert.True(t, errors.As(configErr, &invalid))
+}
+
+func TestValidateValid(t *testing.T) {
+	cfg := &config.Medik{
+		Exams: []config.Exam{vars("env.is-set", "FOO")},
+		Protocols: map[string]config.Protocol{
+			"release": {Exams: []config.Exam{config.NewExam("file.is-dir", map[string]interface{}{"paths": []string{"."}})}},
+		},
+	}
+
+	assert.Nil(t, Validate(cfg))
+}
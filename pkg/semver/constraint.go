@@ -0,0 +1,135 @@
+package semver
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A set of version ranges like `>=1.21 <2 || ^3.1`
+// Comparators separated by spaces or commas must all match, while `||` separates alternatives
+type Constraint struct {
+	raw    string
+	ranges [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version Version
+}
+
+var comparatorRegex = regexp.MustCompile(`^(>=|<=|!=|==|=|>|<|\^|~)?\s*(v?[0-9][^\s,]*)`)
+
+// Parses a constraint. Supported operators are `=`, `==`, `!=`, `>`, `>=`, `<`, `<=`,
+// `^` (compatible with, same major) and `~` (approximately, same minor). No operator means `=`
+func ParseConstraint(raw string) (Constraint, error) {
+	c := Constraint{raw: raw}
+
+	for _, alternative := range strings.Split(raw, "||") {
+		comparators := []comparator{}
+		rest := strings.TrimSpace(alternative)
+
+		for rest != "" {
+			match := comparatorRegex.FindStringSubmatch(rest)
+			if match == nil {
+				return c, &ConstraintError{Constraint: raw, Message: "unexpected `" + rest + "`"}
+			}
+
+			version, err := Parse(match[2])
+			if err != nil {
+				return c, &ConstraintError{Constraint: raw, Message: err.Error()}
+			}
+
+			comparators = append(comparators, expand(match[1], version, strings.Count(match[2], "."))...)
+			rest = strings.TrimLeft(rest[len(match[0]):], " \t,")
+		}
+
+		if len(comparators) == 0 {
+			return c, &ConstraintError{Constraint: raw, Message: "empty range"}
+		}
+
+		c.ranges = append(c.ranges, comparators)
+	}
+
+	return c, nil
+}
+
+// Converts `^` and `~` into a pair of simple comparators
+// `dots` is the number of dots in the written version, used to know how precise it was
+func expand(op string, v Version, dots int) []comparator {
+	switch op {
+	case "^":
+		upper := Version{Major: v.Major + 1}
+		if v.Major == 0 && dots > 0 {
+			upper = Version{Minor: v.Minor + 1}
+			if v.Minor == 0 && dots > 1 {
+				upper = Version{Patch: v.Patch + 1}
+			}
+		}
+		return []comparator{{">=", v}, {"<", upper}}
+	case "~":
+		upper := Version{Major: v.Major, Minor: v.Minor + 1}
+		if dots == 0 {
+			upper = Version{Major: v.Major + 1}
+		}
+		return []comparator{{">=", v}, {"<", upper}}
+	case "", "==":
+		return []comparator{{"=", v}}
+	default:
+		return []comparator{{op, v}}
+	}
+}
+
+// Checks if a version satisfies the constraint
+func (c Constraint) Check(v Version) bool {
+	for _, comparators := range c.ranges {
+		ok := true
+
+		for _, comp := range comparators {
+			if !comp.check(v) {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c comparator) check(v Version) bool {
+	cmp := v.Compare(c.version)
+
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// An error describing a constraint that couldn't be parsed
+type ConstraintError struct {
+	Constraint,
+	Message string
+}
+
+func (e *ConstraintError) Error() string {
+	return "invalid version constraint '" + e.Constraint + "': " + e.Message
+}
@@ -0,0 +1,193 @@
+// This package implements parsing, comparison and constraint matching of semantic versions
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A semantic version like `1.21.3-rc.1+build.5`
+// Build metadata is kept but ignored on comparisons, as the semver spec requires
+type Version struct {
+	Major,
+	Minor,
+	Patch int
+	Prerelease []string
+	Build      string
+}
+
+// Parses a version. A leading `v` is accepted and missing minor and patch numbers default to 0,
+// so `v1.21` is parsed as `1.21.0`. Use ParseStrict to require the full `MAJOR.MINOR.PATCH` form
+func Parse(raw string) (Version, error) {
+	return parse(strings.TrimPrefix(strings.TrimSpace(raw), "v"), false)
+}
+
+// Parses a version that must strictly follow the semver 2.0.0 spec
+func ParseStrict(raw string) (Version, error) {
+	return parse(raw, true)
+}
+
+func parse(raw string, strict bool) (Version, error) {
+	var v Version
+
+	rest, build, hasBuild := strings.Cut(raw, "+")
+	if hasBuild {
+		if err := validIdentifiers(build, false); err != nil {
+			return v, &VersionError{Version: raw, Message: "invalid build metadata: " + err.Error()}
+		}
+		v.Build = build
+	}
+
+	core, prerelease, hasPrerelease := strings.Cut(rest, "-")
+	if hasPrerelease {
+		if err := validIdentifiers(prerelease, true); err != nil {
+			return v, &VersionError{Version: raw, Message: "invalid prerelease: " + err.Error()}
+		}
+		v.Prerelease = strings.Split(prerelease, ".")
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 || (strict && len(parts) != 3) {
+		return v, &VersionError{Version: raw, Message: "expected MAJOR.MINOR.PATCH"}
+	}
+
+	numbers := [3]int{}
+	for i, part := range parts {
+		n, err := parseNumber(part)
+		if err != nil {
+			return v, &VersionError{Version: raw, Message: err.Error()}
+		}
+		numbers[i] = n
+	}
+
+	v.Major, v.Minor, v.Patch = numbers[0], numbers[1], numbers[2]
+
+	return v, nil
+}
+
+func parseNumber(part string) (int, error) {
+	if part == "" {
+		return 0, fmt.Errorf("empty version number")
+	}
+
+	if len(part) > 1 && part[0] == '0' {
+		return 0, fmt.Errorf("version number %v has leading zeros", part)
+	}
+
+	for _, c := range part {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("version number %v is not numeric", part)
+		}
+	}
+
+	return strconv.Atoi(part)
+}
+
+func validIdentifiers(raw string, noLeadingZeros bool) error {
+	for _, id := range strings.Split(raw, ".") {
+		if id == "" {
+			return fmt.Errorf("empty identifier")
+		}
+
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return fmt.Errorf("identifier %v has invalid character %q", id, c)
+			}
+		}
+
+		if noLeadingZeros && numeric && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("numeric identifier %v has leading zeros", id)
+		}
+	}
+
+	return nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// Compares two versions following the semver precedence rules
+// Returns -1 if v < o, 0 if v == o and 1 if v > o
+func (v Version) Compare(o Version) int {
+	if c := compareInt(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	// A version without prerelease has higher precedence than one with it
+	switch {
+	case len(v.Prerelease) == 0 && len(o.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(o.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(o.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], o.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInt(len(v.Prerelease), len(o.Prerelease))
+}
+
+func compareIdentifier(a, b string) int {
+	an, aerr := strconv.Atoi(a)
+	bn, berr := strconv.Atoi(b)
+
+	switch {
+	case aerr == nil && berr == nil:
+		return compareInt(an, bn)
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// An error describing a version that couldn't be parsed
+type VersionError struct {
+	Version,
+	Message string
+}
+
+func (e *VersionError) Error() string {
+	return "invalid version '" + e.Version + "': " + e.Message
+}
@@ -0,0 +1,102 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.21")
+	assert.Nil(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 21}, v)
+
+	v, err = Parse("1.2.3-rc.1+build.5")
+	assert.Nil(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}, Build: "build.5"}, v)
+	assert.Equal(t, "1.2.3-rc.1+build.5", v.String())
+
+	_, err = Parse("1.2.3.4")
+	assert.NotNil(t, err)
+
+	_, err = Parse("1.x")
+	assert.NotNil(t, err)
+
+	_, err = Parse("1.02")
+	assert.NotNil(t, err)
+}
+
+func TestParseStrict(t *testing.T) {
+	_, err := ParseStrict("1.2.3")
+	assert.Nil(t, err)
+
+	_, err = ParseStrict("1.2")
+	assert.NotNil(t, err)
+
+	_, err = ParseStrict("v1.2.3")
+	assert.NotNil(t, err)
+
+	_, err = ParseStrict("1.2.3-01")
+	assert.NotNil(t, err)
+
+	_, err = ParseStrict("1.2.3-alpha..1")
+	assert.NotNil(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := Parse(ordered[i])
+		b, _ := Parse(ordered[i+1])
+		assert.Equal(t, -1, a.Compare(b), "%v < %v", a, b)
+		assert.Equal(t, 1, b.Compare(a), "%v > %v", b, a)
+	}
+
+	a, _ := Parse("1.0.0+a")
+	b, _ := Parse("1.0.0+b")
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+func TestConstraint(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{">=1.21 <2", "1.21.0", true},
+		{">=1.21 <2", "1.22.5", true},
+		{">=1.21 <2", "1.20.9", false},
+		{">=1.21 <2", "2.0.0", false},
+		{">= 1.21, < 2", "1.23.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"!=1.2.3", "1.2.3", false},
+		{"^1.2", "1.9.0", true},
+		{"^1.2", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"<1 || >=3", "0.5.0", true},
+		{"<1 || >=3", "2.0.0", false},
+		{"<1 || >=3", "3.1.0", true},
+	}
+
+	for _, c := range cases {
+		constraint, err := ParseConstraint(c.constraint)
+		assert.Nil(t, err, c.constraint)
+
+		version, err := Parse(c.version)
+		assert.Nil(t, err, c.version)
+
+		assert.Equal(t, c.expected, constraint.Check(version), "%v %v", c.constraint, c.version)
+	}
+}
+
+func TestConstraintInvalid(t *testing.T) {
+	for _, raw := range []string{"", "abc", ">=1.x", "1.0 ||", ">>1"} {
+		_, err := ParseConstraint(raw)
+		assert.NotNil(t, err, raw)
+	}
+}
@@ -1,15 +1,27 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
-	"github.com/OJarrisonn/medik/pkg/cmd"
-	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/OJarrisonn/medik/pkg/runner"
 )
 
 func TestRunDemo1(t *testing.T) {
-	medik.ConfigFile = "../samples/medik.demo1.yaml"
-	medik.EnvFile = "../samples/.env.demo1"
+	t.Parallel()
 
-	cmd.Execute(nil)
+	r := runner.New()
+	r.ConfigFile = "../samples/medik.demo1.yaml"
+	r.EnvFiles = []string{"../samples/.env.demo1"}
+	r.Out = &bytes.Buffer{}
+
+	result, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if !result.Healthy() {
+		t.Errorf("Run() reported an unhealthy environment:\n%v", r.Out)
+	}
 }
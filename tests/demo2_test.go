@@ -1,15 +1,28 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
-	"github.com/OJarrisonn/medik/pkg/cmd"
-	"github.com/OJarrisonn/medik/pkg/medik"
+	"github.com/OJarrisonn/medik/pkg/runner"
 )
 
 func TestRunDemo2(t *testing.T) {
-	medik.ConfigFile = "../samples/medik.demo2.yaml"
-	medik.EnvFile = "../samples/.env.demo2"
+	t.Parallel()
 
-	cmd.Execute([]string{"dingle-bell"})
+	r := runner.New()
+	r.ConfigFile = "../samples/medik.demo2.yaml"
+	r.EnvFiles = []string{"../samples/.env.demo2"}
+	r.Protocols = []string{"dingle-bell"}
+	r.Out = &bytes.Buffer{}
+
+	result, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if !result.Healthy() {
+		t.Errorf("Run() reported an unhealthy environment:\n%v", r.Out)
+	}
 }